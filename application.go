@@ -8,6 +8,23 @@ import (
 	"github.com/gdamore/tcell"
 )
 
+// queueSize is the size of the channel used to queue application updates.
+const queueSize = 100
+
+// MouseAction indicates one of the actions the mouse is logically doing. It is
+// derived by the Application from the raw tcell mouse events.
+type MouseAction int
+
+// Available mouse actions.
+const (
+	MouseMove MouseAction = iota
+	MouseLeftDown
+	MouseLeftUp
+	MouseLeftClick
+	MouseScrollUp
+	MouseScrollDown
+)
+
 // Application represents the top node of an application.
 //
 // It is not strictly required to use this class as none of the other classes
@@ -43,11 +60,27 @@ type Application struct {
 
 	// If this value is true, the application has entered suspended mode.
 	suspended bool
+
+	// Functions queued from goroutines, to be executed in the event loop.
+	updates chan func()
+
+	// Whether or not mouse events are reported by the screen.
+	enableMouse bool
+
+	// The button state and position of the last mouse event.
+	lastMouseButtons tcell.ButtonMask
+	lastMouseX       int
+	lastMouseY       int
+
+	// The position where the left mouse button was last pressed.
+	mouseDownX, mouseDownY int
 }
 
 // NewApplication creates and returns a new application.
 func NewApplication() *Application {
-	return &Application{}
+	return &Application{
+		updates: make(chan func(), queueSize),
+	}
 }
 
 // SetInputCapture sets a function which captures all key events before they are
@@ -70,22 +103,79 @@ func (a *Application) GetInputCapture() func(event *tcell.EventKey) *tcell.Event
 	return a.inputCapture
 }
 
+// EnableMouse enables or disables mouse events. When enabled, mouse events are
+// translated into mouse actions (see MouseAction) and passed to the mouse
+// handlers of the primitives, starting with the primitive which has focus.
+func (a *Application) EnableMouse(enable bool) *Application {
+	a.Lock()
+	defer a.Unlock()
+	if enable != a.enableMouse && a.screen != nil {
+		if enable {
+			a.screen.EnableMouse()
+		} else {
+			a.screen.DisableMouse()
+		}
+	}
+	a.enableMouse = enable
+	return a
+}
+
+// SetScreen allows you to provide your own tcell.Screen object, e.g. a
+// tcell.SimulationScreen for testing. For most applications, this is not
+// needed as Run() creates a screen for the current terminal.
+//
+// This function is typically called before the first call to Run(). Init() need
+// not be called on the screen, Run() will do that. If the application is
+// already running, the current screen is finalized and replaced with the new
+// one, which is then initialized here.
+func (a *Application) SetScreen(screen tcell.Screen) *Application {
+	a.Lock()
+	if a.screen == nil {
+		// Run() has not been called yet.
+		a.screen = screen
+		a.Unlock()
+		return a
+	}
+
+	// Replace the running screen.
+	oldScreen := a.screen
+	if err := screen.Init(); err != nil {
+		a.Unlock()
+		panic(err)
+	}
+	if a.enableMouse {
+		screen.EnableMouse()
+	}
+	a.screen = screen
+	a.suspended = true // Keeps the event loop running.
+	a.Unlock()
+	oldScreen.Fini()
+	a.Draw()
+
+	return a
+}
+
 // Run starts the application and thus the event loop. This function returns
 // when Stop() was called.
 func (a *Application) Run() error {
 	var err error
 	a.Lock()
 
-	// Make a screen.
-	a.screen, err = tcell.NewScreen()
-	if err != nil {
-		a.Unlock()
-		return err
+	// Make a screen if there is none yet.
+	if a.screen == nil {
+		a.screen, err = tcell.NewScreen()
+		if err != nil {
+			a.Unlock()
+			return err
+		}
 	}
 	if err = a.screen.Init(); err != nil {
 		a.Unlock()
 		return err
 	}
+	if a.enableMouse {
+		a.screen.EnableMouse()
+	}
 
 	// We catch panics to clean up because they mess up the terminal.
 	defer func() {
@@ -101,6 +191,9 @@ func (a *Application) Run() error {
 	a.Unlock()
 	a.Draw()
 
+	// Execute updates which were queued before the application was started.
+	a.runUpdates()
+
 	// Start event loop.
 	for {
 		a.Lock()
@@ -114,11 +207,12 @@ func (a *Application) Run() error {
 		}
 
 		// Wait for next event.
-		event := a.screen.PollEvent()
+		event := screen.PollEvent()
 		if event == nil {
 			a.Lock()
 			if a.suspended {
-				// This screen was renewed due to suspended mode.
+				// This screen was renewed due to suspended mode or replaced
+				// with SetScreen().
 				a.suspended = false
 				a.Unlock()
 				continue // Resume.
@@ -157,18 +251,150 @@ func (a *Application) Run() error {
 					a.Draw()
 				}
 			}
+		case *tcell.EventMouse:
+			if a.fireMouseActions(event) {
+				a.Draw()
+			}
 		case *tcell.EventResize:
 			a.Lock()
 			screen := a.screen
 			a.Unlock()
 			screen.Clear()
 			a.Draw()
+		case *tcell.EventInterrupt:
+			// Posted by QueueUpdate() to wake us up. The queued functions are
+			// executed below.
 		}
+
+		// Execute any queued updates.
+		a.runUpdates()
 	}
 
 	return nil
 }
 
+// fireMouseActions translates a tcell mouse event into mouse actions and passes
+// them to the primitive which has focus first, then to the root primitive. It
+// returns true if any of the actions was consumed.
+func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed bool) {
+	x, y := event.Position()
+	buttons := event.Buttons()
+
+	// Determine the actions from the change in the button state.
+	var actions []MouseAction
+	leftDown := buttons&tcell.Button1 != 0
+	wasLeftDown := a.lastMouseButtons&tcell.Button1 != 0
+	switch {
+	case leftDown && !wasLeftDown:
+		actions = append(actions, MouseLeftDown)
+		a.mouseDownX, a.mouseDownY = x, y
+	case !leftDown && wasLeftDown:
+		actions = append(actions, MouseLeftUp)
+		if x == a.mouseDownX && y == a.mouseDownY {
+			actions = append(actions, MouseLeftClick)
+		}
+	case x != a.lastMouseX || y != a.lastMouseY:
+		actions = append(actions, MouseMove)
+	}
+	if buttons&tcell.WheelUp != 0 {
+		actions = append(actions, MouseScrollUp)
+	}
+	if buttons&tcell.WheelDown != 0 {
+		actions = append(actions, MouseScrollDown)
+	}
+	a.lastMouseButtons = buttons
+	a.lastMouseX, a.lastMouseY = x, y
+
+	// Pass the actions on to the primitives.
+	setFocus := func(p Primitive) {
+		a.SetFocus(p)
+	}
+	for _, action := range actions {
+		a.RLock()
+		focus := a.focus
+		root := a.root
+		a.RUnlock()
+		if focus != nil {
+			if handler := focus.MouseHandler(); handler != nil && handler(action, event, setFocus) {
+				consumed = true
+				continue
+			}
+		}
+		if root != nil && root != focus {
+			if handler := root.MouseHandler(); handler != nil && handler(action, event, setFocus) {
+				consumed = true
+			}
+		}
+	}
+
+	return
+}
+
+// runUpdates executes all functions which are currently waiting in the update
+// queue. It must only be called from the event loop.
+func (a *Application) runUpdates() {
+	updates := a.updateQueue()
+	for {
+		select {
+		case update := <-updates:
+			update()
+		default:
+			return
+		}
+	}
+}
+
+// updateQueue returns the channel on which updates are queued. It is created
+// here for applications which were not created with NewApplication().
+func (a *Application) updateQueue() chan func() {
+	a.Lock()
+	defer a.Unlock()
+	if a.updates == nil {
+		a.updates = make(chan func(), queueSize)
+	}
+	return a.updates
+}
+
+// QueueUpdate is used to synchronize access to primitives from non-main
+// goroutines. The provided function will be executed as part of the event loop
+// and thus will not cause race conditions with other such update functions or
+// the Draw() function.
+//
+// Note that Draw() is not implicitly called after the execution of f as that
+// may not be desirable. You can call Draw() from f if the screen should be
+// refreshed after each update. Alternatively, use QueueUpdateDraw() to follow
+// up with an immediate refresh of the screen.
+//
+// This function blocks if the update queue is full. It should therefore not be
+// called from the event loop itself (e.g. from an input handler) as there is
+// no need to synchronize access there anyway.
+func (a *Application) QueueUpdate(f func()) *Application {
+	a.updateQueue() <- f
+
+	// Wake up the event loop. If the screen is not there yet (or anymore), the
+	// update is executed when Run() starts. If the screen's event queue is
+	// full, the wake-up can be dropped: the update queue is also drained after
+	// each of the events that are still waiting.
+	a.RLock()
+	screen := a.screen
+	a.RUnlock()
+	if screen != nil {
+		screen.PostEvent(tcell.NewEventInterrupt(nil))
+	}
+
+	return a
+}
+
+// QueueUpdateDraw works like QueueUpdate() except it refreshes the screen
+// immediately after executing f.
+func (a *Application) QueueUpdateDraw(f func()) *Application {
+	a.QueueUpdate(func() {
+		f()
+		a.Draw()
+	})
+	return a
+}
+
 // Stop stops the application, causing Run() to return.
 func (a *Application) Stop() {
 	a.RLock()
@@ -224,6 +450,9 @@ func (a *Application) Suspend(f func()) bool {
 		a.Unlock()
 		panic(err)
 	}
+	if a.enableMouse {
+		a.screen.EnableMouse()
+	}
 	a.Unlock()
 	a.Draw()
 
@@ -233,10 +462,15 @@ func (a *Application) Suspend(f func()) bool {
 
 // Draw refreshes the screen. It calls the Draw() function of the application's
 // root primitive and then syncs the screen buffer.
+//
+// Draw must be called from the event loop (e.g. from an input handler or a
+// function passed to QueueUpdate()). Other goroutines which change primitives
+// and need to refresh the screen should use QueueUpdateDraw() instead.
 func (a *Application) Draw() *Application {
 	a.RLock()
 	screen := a.screen
 	root := a.root
+	focus := a.focus
 	fullscreen := a.rootFullscreen
 	before := a.beforeDraw
 	after := a.afterDraw
@@ -264,6 +498,12 @@ func (a *Application) Draw() *Application {
 	// Draw all primitives.
 	root.Draw(screen)
 
+	// Pop-ups of the focused primitive (e.g. an input field's suggestions)
+	// cover everything else.
+	if overlay, ok := focus.(interface{ drawOverlay(tcell.Screen) }); ok {
+		overlay.drawOverlay(screen)
+	}
+
 	// Call after handler if there is one.
 	if after != nil {
 		after(screen)
@@ -67,6 +67,16 @@ type Box struct {
 	// nothing should be forwarded).
 	inputCapture func(event *tcell.EventKey) *tcell.EventKey
 
+	// An optional capture function which receives a mouse event and returns the
+	// action and event to be forwarded to the primitive's default mouse handler
+	// (nil if nothing should be forwarded).
+	mouseCapture func(action MouseAction, event *tcell.EventMouse) (MouseAction, *tcell.EventMouse)
+
+	// Optional functions which are called before and after the primitive's
+	// default key or mouse handler processes an event. Forms use them to
+	// detect changes of their items made by the user.
+	beforeEvent, afterEvent func()
+
 	// An optional function which is called before the box is drawn.
 	draw func(screen tcell.Screen, x, y, width, height int) (int, int, int, int)
 }
@@ -221,7 +231,9 @@ func (b *Box) WrapInputHandler(inputHandler func(*tcell.EventKey, func(p Primiti
 			event = b.inputCapture(event)
 		}
 		if event != nil && inputHandler != nil {
+			b.notifyBeforeEvent()
 			inputHandler(event, setFocus)
+			b.notifyAfterEvent()
 		}
 	}
 }
@@ -249,6 +261,86 @@ func (b *Box) GetInputCapture() func(event *tcell.EventKey) *tcell.EventKey {
 	return b.inputCapture
 }
 
+// InRect returns true if the given coordinate is within the bounds of the box's
+// rectangle.
+func (b *Box) InRect(x, y int) bool {
+	rectX, rectY, width, height := b.GetRect()
+	return x >= rectX && x < rectX+width && y >= rectY && y < rectY+height
+}
+
+// WrapMouseHandler wraps a mouse handler (see MouseHandler()) with the
+// functionality to capture mouse events (see SetMouseCapture()) before passing
+// them on to the provided (default) mouse handler.
+//
+// This is only meant to be used by subclassing primitives.
+func (b *Box) WrapMouseHandler(mouseHandler func(MouseAction, *tcell.EventMouse, func(p Primitive)) bool) func(MouseAction, *tcell.EventMouse, func(p Primitive)) bool {
+	return func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		if b.mouseCapture != nil {
+			action, event = b.mouseCapture(action, event)
+		}
+		if event != nil && mouseHandler != nil {
+			b.notifyBeforeEvent()
+			consumed = mouseHandler(action, event, setFocus)
+			if consumed {
+				b.notifyAfterEvent()
+			}
+		}
+		return
+	}
+}
+
+// setEventFuncs installs functions which are called before and after the box's
+// key or mouse handler processes an event.
+func (b *Box) setEventFuncs(before, after func()) {
+	b.beforeEvent = before
+	b.afterEvent = after
+}
+
+// notifyBeforeEvent calls the "before" function installed with
+// setEventFuncs(), if any.
+func (b *Box) notifyBeforeEvent() {
+	if b.beforeEvent != nil {
+		b.beforeEvent()
+	}
+}
+
+// notifyAfterEvent calls the "after" function installed with setEventFuncs(),
+// if any.
+func (b *Box) notifyAfterEvent() {
+	if b.afterEvent != nil {
+		b.afterEvent()
+	}
+}
+
+// MouseHandler returns a handler which sets the focus on the box when the left
+// mouse button is pressed inside of it.
+func (b *Box) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return b.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		if action == MouseLeftDown && b.InRect(event.Position()) {
+			setFocus(b)
+			consumed = true
+		}
+		return
+	})
+}
+
+// SetMouseCapture installs a function which captures mouse events before they
+// are forwarded to the primitive's default mouse handler. This function can
+// then choose to forward that event (or a different one) to the default handler
+// by returning it. If nil is returned, the default handler will not be called.
+//
+// Providing a nil handler will remove a previously existing handler.
+func (b *Box) SetMouseCapture(capture func(action MouseAction, event *tcell.EventMouse) (MouseAction, *tcell.EventMouse)) *Box {
+	b.mouseCapture = capture
+	return b
+}
+
+// GetMouseCapture returns the function installed with SetMouseCapture() or nil
+// if no such function has been installed.
+func (b *Box) GetMouseCapture() func(action MouseAction, event *tcell.EventMouse) (MouseAction, *tcell.EventMouse) {
+	return b.mouseCapture
+}
+
 // SetBackgroundColor sets the box's background color.
 func (b *Box) SetBackgroundColor(color tcell.Color) *Box {
 	b.backgroundColor = color
@@ -151,3 +151,25 @@ func (b *Button) InputHandler() func(event *tcell.EventKey, setFocus func(p Prim
 		}
 	})
 }
+
+// MouseHandler returns the mouse handler for this primitive.
+func (b *Button) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return b.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		if b.hidden || b.disable || !b.InRect(event.Position()) {
+			return false
+		}
+
+		// Process mouse event.
+		switch action {
+		case MouseLeftDown:
+			setFocus(b)
+			consumed = true
+		case MouseLeftClick: // Selected.
+			if b.selected != nil {
+				b.selected()
+			}
+			consumed = true
+		}
+		return
+	})
+}
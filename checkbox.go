@@ -32,6 +32,10 @@ type Checkbox struct {
 	// The text to be displayed before the input area.
 	label string
 
+	// An optional name which identifies the item in a form, see
+	// Form.GetValues().
+	name string
+
 	// The screen width of the label area. A value of 0 means use the width of
 	// the label text.
 	labelWidth int
@@ -98,6 +102,34 @@ func (c *Checkbox) GetLabel() string {
 	return c.label
 }
 
+// SetName sets a name which identifies the item in a form. It is used instead
+// of the label as the key in Form.GetValues() and Form.SetValues().
+func (c *Checkbox) SetName(name string) *Checkbox {
+	c.name = name
+	return c
+}
+
+// GetName returns the name set with SetName().
+func (c *Checkbox) GetName() string {
+	return c.name
+}
+
+// GetValue returns whether or not the box is checked. See FormItemValue.
+func (c *Checkbox) GetValue() interface{} {
+	return c.checked
+}
+
+// SetValue checks or unchecks the box. The value must be a bool. See
+// FormItemValue.
+func (c *Checkbox) SetValue(value interface{}) error {
+	checked, ok := value.(bool)
+	if !ok {
+		return fmt.Errorf("checkbox value must be a bool, got %T", value)
+	}
+	c.SetChecked(checked)
+	return nil
+}
+
 // GetLabelWidth returns label width.
 func (c *Checkbox) GetLabelWidth() int {
 	return StringWidth(strings.Replace(c.subLabel+c.label, "%s", "", -1))
@@ -298,3 +330,26 @@ func (c *Checkbox) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 		}
 	})
 }
+
+// MouseHandler returns the mouse handler for this primitive.
+func (c *Checkbox) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return c.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		if c.disable || !c.InRect(event.Position()) {
+			return false
+		}
+
+		// Process mouse event.
+		switch action {
+		case MouseLeftDown:
+			setFocus(c)
+			consumed = true
+		case MouseLeftClick: // Check.
+			c.checked = !c.checked
+			if c.changed != nil {
+				c.changed(c.checked)
+			}
+			consumed = true
+		}
+		return
+	})
+}
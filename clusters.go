@@ -0,0 +1,141 @@
+package tview
+
+import (
+	"unicode"
+
+	runewidth "github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// textCluster is one grapheme cluster (a user-perceived character) of a text
+// being edited, e.g. in an InputField or a TextArea.
+type textCluster struct {
+	start, end int    // The byte positions of the cluster in the text.
+	runes      []rune // The cluster's runes.
+	width      int    // The cluster's screen width.
+}
+
+// textClusters are the grapheme clusters of a text, in order. All positions
+// used with them are byte positions in the text.
+type textClusters []textCluster
+
+// splitClusters splits the given text into grapheme clusters.
+func splitClusters(text string) (clusters textClusters) {
+	graphemes := uniseg.NewGraphemes(text)
+	for graphemes.Next() {
+		start, end := graphemes.Positions()
+		runes := graphemes.Runes()
+		clusters = append(clusters, textCluster{start: start, end: end, runes: runes, width: clusterWidth(runes)})
+	}
+	return
+}
+
+// clusterWidth returns the screen width of a grapheme cluster. Clusters
+// without a width (e.g. tabs) take up one cell.
+func clusterWidth(runes []rune) int {
+	width := runewidth.RuneWidth(runes[0])
+	for _, r := range runes[1:] {
+		if r == '\ufe0f' { // Emoji presentation.
+			width = 2
+		}
+	}
+	if width < 1 {
+		width = 1
+	}
+	return width
+}
+
+// snap returns the given position moved back to the start of the cluster it
+// falls into, limited to the valid range.
+func (c textClusters) snap(position int) int {
+	if position <= 0 || len(c) == 0 {
+		return 0
+	}
+	for _, cluster := range c {
+		if position < cluster.end {
+			return cluster.start
+		}
+	}
+	return c[len(c)-1].end
+}
+
+// previous returns the position of the cluster before the given position.
+func (c textClusters) previous(position int) int {
+	for index := len(c) - 1; index >= 0; index-- {
+		if c[index].start < position {
+			return c[index].start
+		}
+	}
+	return 0
+}
+
+// next returns the position of the cluster after the given position.
+func (c textClusters) next(position int) int {
+	for _, cluster := range c {
+		if cluster.end > position {
+			return cluster.end
+		}
+	}
+	if len(c) == 0 {
+		return 0
+	}
+	return c[len(c)-1].end
+}
+
+// width returns the screen width of the text between the two positions.
+func (c textClusters) width(from, to int) (width int) {
+	for _, cluster := range c {
+		if cluster.start >= from && cluster.end <= to {
+			width += cluster.width
+		}
+	}
+	return
+}
+
+// isWordCluster returns true if the given cluster is part of a word, i.e.
+// starts with a letter, a digit, or an underscore.
+func isWordCluster(cluster textCluster) bool {
+	r := cluster.runes[0]
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// previousWord returns the position of the start of the word before the given
+// position.
+func (c textClusters) previousWord(position int) int {
+	index := len(c) - 1
+	for index >= 0 && c[index].start >= position {
+		index--
+	}
+	for index >= 0 && !isWordCluster(c[index]) {
+		index--
+	}
+	for index >= 0 && isWordCluster(c[index]) {
+		index--
+	}
+	if index < 0 {
+		return 0
+	}
+	return c[index].end
+}
+
+// nextWord returns the position of the end of the word after the given
+// position.
+func (c textClusters) nextWord(position int) int {
+	index := 0
+	for index < len(c) && c[index].end <= position {
+		index++
+	}
+	for index < len(c) && !isWordCluster(c[index]) {
+		index++
+	}
+	for index < len(c) && isWordCluster(c[index]) {
+		index++
+	}
+	if index >= len(c) {
+		if len(c) == 0 {
+			return 0
+		}
+		return c[len(c)-1].end
+	}
+	return c[index].start
+}
@@ -12,7 +12,9 @@ The package implements the following widgets:
   - Table: Scrollable display of tabular data. Table cells, rows, or columns may
     also be highlighted.
   - List: A navigable text list with optional keyboard shortcuts.
+  - TreeView: A navigable tree of hierarchical, optionally lazily loaded nodes.
   - InputField: One-line input fields to enter text.
+  - TextArea: Multi-line input fields to enter and edit text.
   - DropDown: Drop-down selection fields.
   - Checkbox: Selectable checkbox for boolean values.
   - Button: Buttons which get activated when the user selects them.
@@ -146,6 +148,11 @@ interface which is used to override functions in subclassing types.
 The tview package is based on https://github.com/gdamore/tcell. It uses types
 and constants from that package (e.g. colors and keyboard values).
 
-This package does not process mouse input (yet).
+Mouse Support
+
+Mouse events are disabled by default. Call Application.EnableMouse() to enable
+them. Primitives receive logical mouse actions (clicks, scroll wheel movements)
+through their MouseHandler() function. Clicking a primitive usually sets the
+focus on it.
 */
 package tview
@@ -3,6 +3,7 @@ package tview
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/gdamore/tcell"
 	runewidth "github.com/mattn/go-runewidth"
@@ -10,9 +11,12 @@ import (
 
 // DropDownOption is one option that can be selected in a drop-down primitive.
 type DropDownOption struct {
-	Name     string
-	Text     string // The text to be displayed in the drop-down.
-	Selected func() // The (optional) callback for when this option was selected.
+	Name        string
+	Text        string // The text to be displayed in the drop-down.
+	Selected    func() // The (optional) callback for when this option was selected.
+	Group       string // The (optional) group, shown as a header above the group's first option.
+	Description string // An (optional) secondary text shown next to the option's text.
+	Disabled    bool   // If set to true, the option cannot be selected.
 }
 
 // NewDropDownOption returns a new option for dropdown
@@ -23,6 +27,19 @@ func NewDropDownOption(name, text string) *DropDownOption {
 	}
 }
 
+// Search modes for drop-downs, see DropDown.SetSearchMode().
+const (
+	// Typing selects the first option which starts with the typed text.
+	DropDownSearchPrefix = iota
+
+	// Typing hides all options which do not contain the typed text.
+	DropDownSearchSubstring
+
+	// Typing hides all options which do not contain the typed characters in
+	// the same order (but not necessarily next to each other).
+	DropDownSearchFuzzy
+)
+
 // DropDown implements a selection widget whose options become visible in a
 // drop-down list when activated.
 //
@@ -44,15 +61,48 @@ type DropDown struct {
 	// Set to true if the options are visible and selectable.
 	open bool
 
-	// The runes typed so far to directly access one of the list items.
+	// The runes typed so far to directly access one of the list items. In the
+	// filtering search modes, this is the filter text.
 	prefix string
 
+	// How typed text selects options, one of the DropDownSearch constants.
+	searchMode int
+
+	// The index of the option shown in each row of the list, -1 for rows which
+	// do not contain an option (group headers and status texts).
+	rows []int
+
+	// The list row which was current before the last navigation.
+	listRow int
+
+	// The color of option descriptions.
+	descriptionTextColor tcell.Color
+
+	// The color of characters which matched the filter text.
+	matchTextColor tcell.Color
+
+	// The text shown in the list if no option matches the filter text.
+	noMatchesText string
+
+	// If options are supplied by an OptionProvider, this loads them.
+	loader *optionLoader
+
+	// The text shown in the list while options are being loaded.
+	loadingText string
+
+	// Set to true while the list is being filled.
+	rebuilding bool
+
 	// The list element for the options.
 	list *List
 
 	// The text to be displayed before the input area.
 	label string
 
+	// An optional name which identifies the item in a form, see
+	// Form.GetValues().
+	name string
+
 	// The label color.
 	labelColor tcell.Color
 
@@ -99,6 +149,10 @@ func NewDropDown() *DropDown {
 		fieldBackgroundColor: Styles.FieldBackgroundColor,
 		fieldTextColor:       Styles.FieldTextColor,
 		prefixTextColor:      Styles.ContrastSecondaryTextColor,
+		matchTextColor:       Styles.SecondaryTextColor,
+		noMatchesText:        "No matches",
+		descriptionTextColor: Styles.TertiaryTextColor,
+		loadingText:          DefaultLoadingText,
 		align:                AlignLeft,
 		labelFiller:          " ",
 	}
@@ -114,8 +168,7 @@ func NewDropDown() *DropDown {
 func (d *DropDown) SetCurrentOptionByName(name string) *DropDown {
 	for i := 0; i < len(d.options); i++ {
 		if d.options[i].Name == name {
-			d.currentOption = i
-			d.list.SetCurrentItem(i)
+			d.SetCurrentOption(i)
 			break
 		}
 	}
@@ -126,10 +179,32 @@ func (d *DropDown) SetCurrentOptionByName(name string) *DropDown {
 // be a negative value to indicate that no option is currently selected.
 func (d *DropDown) SetCurrentOption(index int) *DropDown {
 	d.currentOption = index
-	d.list.SetCurrentItem(index)
+	if row := d.optionRow(index); row >= 0 {
+		d.list.SetCurrentItem(row)
+	}
 	return d
 }
 
+// optionRow returns the list row which shows the option with the given index
+// or -1 if the option is not shown.
+func (d *DropDown) optionRow(index int) int {
+	if index < 0 {
+		return -1
+	}
+	for row, option := range d.rows {
+		if option == index {
+			return row
+		}
+	}
+	return -1
+}
+
+// selectableRow returns whether the given list row shows an option which can
+// be selected.
+func (d *DropDown) selectableRow(row int) bool {
+	return row >= 0 && row < len(d.rows) && d.rows[row] >= 0 && !d.options[d.rows[row]].Disabled
+}
+
 // GetCurrentOption returns the index of the currently selected option as well
 // as its text. If no option was selected, -1 and an empty string is returned.
 func (d *DropDown) GetCurrentOption() (int, string) {
@@ -156,6 +231,47 @@ func (d *DropDown) GetLabel() string {
 	return d.label
 }
 
+// SetName sets a name which identifies the item in a form. It is used instead
+// of the label as the key in Form.GetValues() and Form.SetValues().
+func (d *DropDown) SetName(name string) *DropDown {
+	d.name = name
+	return d
+}
+
+// GetName returns the name set with SetName().
+func (d *DropDown) GetName() string {
+	return d.name
+}
+
+// GetValue returns the name of the selected option or an empty string if no
+// option is selected. See FormItemValue.
+func (d *DropDown) GetValue() interface{} {
+	if d.currentOption < 0 || d.currentOption >= len(d.options) {
+		return ""
+	}
+	return d.options[d.currentOption].Name
+}
+
+// SetValue selects the option with the given name. The value must be a
+// string, an empty string selects no option. See FormItemValue.
+func (d *DropDown) SetValue(value interface{}) error {
+	name, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("drop-down value must be a string, got %T", value)
+	}
+	if name == "" {
+		d.SetCurrentOption(-1)
+		return nil
+	}
+	for index, option := range d.options {
+		if option.Name == name {
+			d.SetCurrentOption(index)
+			return nil
+		}
+	}
+	return fmt.Errorf("drop-down has no option %q", name)
+}
+
 // SetLabelWidth sets the screen width of the label. A value of 0 will cause the
 // primitive to use the width of the label string.
 func (d *DropDown) SetLabelWidth(width int) *DropDown {
@@ -194,6 +310,123 @@ func (d *DropDown) SetPrefixTextColor(color tcell.Color) *DropDown {
 	return d
 }
 
+// SetSearchMode sets how typing selects options when the list of options is
+// open:
+//
+//   - DropDownSearchPrefix (the default): The first option which starts with
+//     the typed text is selected.
+//   - DropDownSearchSubstring: Only options which contain the typed text are
+//     shown, ignoring case.
+//   - DropDownSearchFuzzy: Only options which contain the typed characters in
+//     the same order are shown, ignoring case.
+//
+// In the filtering modes, the matched characters are highlighted (see
+// SetMatchTextColor()).
+func (d *DropDown) SetSearchMode(mode int) *DropDown {
+	d.searchMode = mode
+	d.prefix = ""
+	d.filter()
+	return d
+}
+
+// SetMatchTextColor sets the color of the characters which matched the filter
+// text in the filtering search modes, see SetSearchMode().
+func (d *DropDown) SetMatchTextColor(color tcell.Color) *DropDown {
+	d.matchTextColor = color
+	return d
+}
+
+// SetDescriptionTextColor sets the color of the option descriptions shown in
+// the list of options, see DropDownOption.
+func (d *DropDown) SetDescriptionTextColor(color tcell.Color) *DropDown {
+	d.descriptionTextColor = color
+	return d
+}
+
+// SetNoMatchesText sets the text which is shown in the list of options if no
+// option matches the filter text, see SetSearchMode().
+func (d *DropDown) SetNoMatchesText(text string) *DropDown {
+	d.noMatchesText = text
+	return d
+}
+
+// SetOptionProvider replaces all options with the ones supplied by the given
+// provider. The first page of options is requested when the list of options
+// is opened. Further pages (see SetOptionPageSize()) are requested when the
+// user navigates to the last loaded option.
+//
+// If an application is provided, options are loaded in a separate goroutine.
+// In the meantime, a placeholder (see SetLoadingText()) is shown. The loaded
+// options are then added through Application.QueueUpdateDraw(). Otherwise,
+// the provider is called directly.
+//
+// Provide nil to stop using a provider. Its options are kept.
+func (d *DropDown) SetOptionProvider(provider OptionProvider, app *Application) *DropDown {
+	if d.loader != nil {
+		d.loader.reset()
+	}
+	if provider == nil {
+		d.loader = nil
+		return d
+	}
+	d.loader = &optionLoader{
+		provider: provider,
+		app:      app,
+	}
+	d.options = nil
+	d.currentOption = -1
+	d.filter()
+	return d
+}
+
+// SetOptionPageSize sets the maximum number of options requested from the
+// option provider at once, see SetOptionProvider(). A value of 0 (the
+// default) requests all options at once.
+func (d *DropDown) SetOptionPageSize(size int) *DropDown {
+	if d.loader != nil {
+		d.loader.pageSize = size
+	}
+	return d
+}
+
+// SetLoadingText sets the text which is shown in the list of options while
+// options are being loaded from the option provider.
+func (d *DropDown) SetLoadingText(text string) *DropDown {
+	d.loadingText = text
+	return d
+}
+
+// ReloadOptions discards all options supplied by the option provider so that
+// they are requested again when the list of options is opened.
+func (d *DropDown) ReloadOptions() *DropDown {
+	if d.loader != nil {
+		d.loader.reset()
+		d.options = nil
+		d.currentOption = -1
+		d.filter()
+	}
+	return d
+}
+
+// loadOptions requests the next page of options from the option provider.
+func (d *DropDown) loadOptions() {
+	d.loader.load(func(options []*ProvidedOption) {
+		for _, option := range options {
+			d.options = append(d.options, &DropDownOption{
+				Name:        option.Name,
+				Text:        option.Text,
+				Group:       option.Group,
+				Description: option.Description,
+				Disabled:    option.Disabled,
+			})
+		}
+		d.filter()
+	})
+	if d.loader.loading {
+		d.filter() // Show the placeholder.
+	}
+}
+
 // SetFormAttributes sets attributes shared by all form items.
 func (d *DropDown) SetFormAttributes(labelWidth, fieldWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
 	if d.fieldWidth == 0 {
@@ -247,7 +480,7 @@ func (d *DropDown) GetFieldAlign() (align int) {
 // callback is called when this option was selected. It may be nil.
 func (d *DropDown) AddOption(option *DropDownOption) *DropDown {
 	d.options = append(d.options, option)
-	d.list.AddItem(option.Text, "", 0, option.Selected)
+	d.filter()
 	return d
 }
 
@@ -256,7 +489,6 @@ func (d *DropDown) AddOption(option *DropDownOption) *DropDown {
 // It will be called with the option's text and its index into the options
 // slice. The "selected" parameter may be nil.
 func (d *DropDown) SetOptions(options []*DropDownOption, selected func(option *DropDownOption, index int)) *DropDown {
-	d.list.Clear()
 	d.options = nil
 	for index, option := range options {
 		func(option *DropDownOption, index int) {
@@ -265,9 +497,10 @@ func (d *DropDown) SetOptions(options []*DropDownOption, selected func(option *D
 					selected(option, index)
 				}
 			}
-			d.AddOption(option)
+			d.options = append(d.options, option)
 		}(option, index)
 	}
+	d.filter()
 	return d
 }
 
@@ -356,11 +589,17 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 	}
 
 	// Draw selected text.
-	if d.open && len(d.prefix) > 0 {
+	if d.open && len(d.prefix) > 0 && d.searchMode != DropDownSearchPrefix {
+		// Show the filter text.
+		Print(screen, Escape(d.prefix), x, y, fieldWidth, AlignLeft, d.prefixTextColor)
+	} else if d.open && len(d.prefix) > 0 {
 		// Show the prefix.
 		Print(screen, d.prefix, x, y, fieldWidth, AlignLeft, d.prefixTextColor)
 		prefixWidth := runewidth.StringWidth(d.prefix)
-		listItemText := d.options[d.list.GetCurrentItem()].Text
+		var listItemText string
+		if row := d.list.GetCurrentItem(); d.selectableRow(row) {
+			listItemText = d.options[d.rows[row]].Text
+		}
 		if prefixWidth < fieldWidth && len(d.prefix) < len(listItemText) {
 			Print(screen, listItemText[len(d.prefix):], x+prefixWidth, y, fieldWidth-prefixWidth, AlignLeft, d.fieldTextColor)
 		}
@@ -381,7 +620,12 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 		lx := x
 		ly := y + 1
 		lwidth := maxWidth
-		lheight := len(d.options)
+		for row := 0; row < d.list.GetItemCount(); row++ {
+			if text, _ := d.list.GetItemText(row); StringWidth(text) > lwidth {
+				lwidth = StringWidth(text)
+			}
+		}
+		lheight := d.list.GetItemCount()
 		_, sheight := screen.Size()
 		if ly+lheight >= sheight && ly-2 > lheight-ly {
 			ly = y - lheight
@@ -397,25 +641,298 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 	}
 }
 
-// InputHandler returns the handler for this primitive.
-func (d *DropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
-	return d.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
-		// A helper function which selects an item in the drop-down list based on
-		// the current prefix.
-		evalPrefix := func() {
-			if len(d.prefix) > 0 {
-				for index, option := range d.options {
-					if strings.HasPrefix(strings.ToLower(option.Text), d.prefix) {
-						d.list.SetCurrentItem(index)
+// evalPrefix selects an item in the drop-down list based on the current
+// prefix.
+func (d *DropDown) evalPrefix() {
+	if len(d.prefix) > 0 {
+		for index, option := range d.options {
+			if !option.Disabled && strings.HasPrefix(strings.ToLower(option.Text), d.prefix) {
+				d.list.SetCurrentItem(d.optionRow(index))
+				return
+			}
+		}
+		// Prefix does not match any item. Remove last rune.
+		r := []rune(d.prefix)
+		d.prefix = string(r[:len(r)-1])
+	}
+}
+
+// filter fills the list with the options which match the filter text and
+// highlights the matched characters. In prefix search mode, all options are
+// shown. Options are preceded by the headers of their groups and followed by
+// their descriptions. If options are loaded from a provider, a row with the
+// loading status is added.
+func (d *DropDown) filter() {
+	d.rebuilding = true
+	defer func() {
+		d.rebuilding = false
+	}()
+
+	current := d.currentOption
+	if row := d.list.GetCurrentItem(); d.open && row >= 0 && row < len(d.rows) && d.rows[row] >= 0 {
+		current = d.rows[row]
+	}
+	d.list.Clear()
+	d.rows = nil
+
+	// Descriptions are aligned after the longest option text.
+	var textWidth int
+	for _, option := range d.options {
+		width := StringWidth(option.Text)
+		if option.Group != "" {
+			width += 2
+		}
+		if width > textWidth {
+			textWidth = width
+		}
+	}
+
+	var (
+		filtering   = d.searchMode != DropDownSearchPrefix
+		filter      = []rune(strings.ToLower(d.prefix))
+		highlight   = fmt.Sprintf("[#%06x::b]", d.matchTextColor.Hex())
+		description = fmt.Sprintf("[#%06x]", d.descriptionTextColor.Hex())
+		group       string
+		matched     bool
+		currentRow  = -1
+	)
+	for index, option := range d.options {
+		text := option.Text
+		if filtering {
+			runes := []rune(stripTags(option.Text))
+			matches := matchOption(runes, filter, d.searchMode == DropDownSearchFuzzy)
+			if matches == nil {
+				continue
+			}
+
+			// Highlight the matched characters.
+			var highlighted strings.Builder
+			for pos, r := range runes {
+				if len(matches) > 0 && matches[0] == pos {
+					highlighted.WriteString(highlight + Escape(string(r)) + "[-::-]")
+					matches = matches[1:]
+				} else {
+					highlighted.WriteString(Escape(string(r)))
+				}
+			}
+			text = highlighted.String()
+		}
+		matched = true
+
+		// Group header.
+		width := StringWidth(option.Text)
+		if option.Group != "" {
+			if option.Group != group {
+				d.list.AddItem("[::b]"+Escape(option.Group), "", 0, nil)
+				d.rows = append(d.rows, -1)
+			}
+			text = "  " + text
+			width += 2
+		}
+		group = option.Group
+
+		if option.Description != "" {
+			text += strings.Repeat(" ", textWidth-width+2) + description + Escape(option.Description)
+		}
+		selected := option.Selected
+		if option.Disabled {
+			text = "[::d]" + text
+			selected = nil
+		}
+		if filtering {
+			selected = nil
+		}
+
+		if index == current {
+			currentRow = len(d.rows)
+		}
+		d.list.AddItem(text, "", 0, selected)
+		d.rows = append(d.rows, index)
+	}
+
+	// Status rows.
+	var status string
+	if d.loader != nil {
+		status = d.loader.status(d.loadingText)
+	}
+	if status != "" {
+		d.list.AddItem(Escape(status), "", 0, nil)
+		d.rows = append(d.rows, -1)
+	} else if filtering && !matched {
+		d.list.AddItem(Escape(d.noMatchesText), "", 0, nil)
+		d.rows = append(d.rows, -1)
+	}
+
+	// Select the current option or the first one which can be selected.
+	if !d.selectableRow(currentRow) {
+		currentRow = -1
+		for row := range d.rows {
+			if d.selectableRow(row) {
+				currentRow = row
+				break
+			}
+		}
+	}
+	if currentRow >= 0 {
+		d.list.SetCurrentItem(currentRow)
+	}
+	d.listRow = d.list.GetCurrentItem()
+}
+
+// matchOption returns the positions of the characters in "text" which match
+// "filter", or nil if the text does not match. Letter case is ignored, the
+// filter must be lower case. Without fuzzy matching, the filter must appear in
+// the text as a whole. With fuzzy matching, its characters must appear in the
+// text in the same order.
+func matchOption(text, filter []rune, fuzzy bool) []int {
+	matches := []int{}
+	if len(filter) == 0 {
+		return matches
+	}
+	if fuzzy {
+		for pos, r := range text {
+			if unicode.ToLower(r) == filter[len(matches)] {
+				matches = append(matches, pos)
+				if len(matches) == len(filter) {
+					return matches
+				}
+			}
+		}
+		return nil
+	}
+	for start := 0; start+len(filter) <= len(text); start++ {
+		matched := true
+		for offset, r := range filter {
+			if unicode.ToLower(text[start+offset]) != r {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			for offset := range filter {
+				matches = append(matches, start+offset)
+			}
+			return matches
+		}
+	}
+	return nil
+}
+
+// openList hands control over to the list of options.
+func (d *DropDown) openList(setFocus func(Primitive)) {
+	d.open = true
+	if d.loader != nil && (!d.loader.started || d.loader.err != nil) {
+		d.loadOptions()
+	}
+	d.filter()
+	d.list.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		if !d.selectableRow(index) {
+			return // A header, status row, or disabled option.
+		}
+		index = d.rows[index]
+		d.notifyBeforeEvent() // The list handles the event, not the drop-down.
+		if d.searchMode != DropDownSearchPrefix {
+			d.prefix = ""
+			d.filter()
+		}
+
+		// An option was selected. Close the list again.
+		d.open = false
+		setFocus(d)
+		d.currentOption = index
+
+		// Trigger "selected" event.
+		if d.options[d.currentOption].Selected != nil {
+			d.options[d.currentOption].Selected()
+		}
+
+		d.notifyAfterEvent()
+	}).SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		if d.rebuilding {
+			return
+		}
+
+		// Skip rows which cannot be selected, preferably in the direction of
+		// navigation.
+		if !d.selectableRow(index) {
+			direction := 1
+			if index < d.listRow {
+				direction = -1
+			}
+			for _, step := range []int{direction, -direction} {
+				for row := index + step; row >= 0 && row < len(d.rows); row += step {
+					if d.selectableRow(row) {
+						d.list.SetCurrentItem(row)
 						return
 					}
 				}
-				// Prefix does not match any item. Remove last rune.
+			}
+			return
+		}
+		d.listRow = index
+
+		// Load the next page when the last option is reached.
+		if d.loader == nil || !d.loader.more || d.loader.loading {
+			return
+		}
+		last := len(d.rows) - 1
+		for last >= 0 && d.rows[last] < 0 {
+			last--
+		}
+		if index >= last {
+			d.loadOptions()
+		}
+	}).SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if d.searchMode != DropDownSearchPrefix {
+			// Edit the filter text.
+			switch event.Key() {
+			case tcell.KeyRune:
+				d.prefix += string(event.Rune())
+			case tcell.KeyBackspace, tcell.KeyBackspace2:
+				if len(d.prefix) == 0 {
+					return nil
+				}
+				r := []rune(d.prefix)
+				d.prefix = string(r[:len(r)-1])
+			default:
+				return event
+			}
+			d.filter()
+			return nil
+		}
+		if event.Key() == tcell.KeyRune {
+			d.prefix += string(event.Rune())
+			d.evalPrefix()
+		} else if event.Key() == tcell.KeyBackspace || event.Key() == tcell.KeyBackspace2 {
+			if len(d.prefix) > 0 {
 				r := []rune(d.prefix)
 				d.prefix = string(r[:len(r)-1])
 			}
+			d.evalPrefix()
+		} else {
+			d.prefix = ""
+		}
+		return event
+	}).SetMouseCapture(func(action MouseAction, event *tcell.EventMouse) (MouseAction, *tcell.EventMouse) {
+		// A click outside of the list closes it. Clicks on the drop-down itself
+		// are handled by its own mouse handler.
+		x, y := event.Position()
+		if action == MouseLeftDown && !d.list.InRect(x, y) && !d.InRect(x, y) {
+			d.open = false
+			d.prefix = ""
+			if d.searchMode != DropDownSearchPrefix {
+				d.filter()
+			}
+			setFocus(d)
 		}
+		return action, event
+	})
+	setFocus(d.list)
+}
 
+// InputHandler returns the handler for this primitive.
+func (d *DropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return d.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		// Process key event.
 		switch key := event.Key(); key {
 		case tcell.KeyEnter, tcell.KeyRune, tcell.KeyDown:
@@ -424,37 +941,12 @@ func (d *DropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 			// If the first key was a letter already, it becomes part of the prefix.
 			if r := event.Rune(); key == tcell.KeyRune && r != ' ' {
 				d.prefix += string(r)
-				evalPrefix()
+				if d.searchMode == DropDownSearchPrefix {
+					d.evalPrefix()
+				}
 			}
 
-			// Hand control over to the list.
-			d.open = true
-			d.list.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
-				// An option was selected. Close the list again.
-				d.open = false
-				setFocus(d)
-				d.currentOption = index
-
-				// Trigger "selected" event.
-				if d.options[d.currentOption].Selected != nil {
-					d.options[d.currentOption].Selected()
-				}
-			}).SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-				if event.Key() == tcell.KeyRune {
-					d.prefix += string(event.Rune())
-					evalPrefix()
-				} else if event.Key() == tcell.KeyBackspace || event.Key() == tcell.KeyBackspace2 {
-					if len(d.prefix) > 0 {
-						r := []rune(d.prefix)
-						d.prefix = string(r[:len(r)-1])
-					}
-					evalPrefix()
-				} else {
-					d.prefix = ""
-				}
-				return event
-			})
-			setFocus(d.list)
+			d.openList(setFocus)
 		case tcell.KeyEscape, tcell.KeyTab, tcell.KeyBacktab:
 			if d.done != nil {
 				d.done(key)
@@ -466,6 +958,38 @@ func (d *DropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 	})
 }
 
+// MouseHandler returns the mouse handler for this primitive.
+func (d *DropDown) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return d.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		if d.disable || !d.InRect(event.Position()) {
+			return false
+		}
+
+		// Process mouse event.
+		switch action {
+		case MouseLeftDown:
+			if !d.open {
+				setFocus(d)
+			}
+			consumed = true
+		case MouseLeftClick:
+			if d.open {
+				d.open = false
+				d.prefix = ""
+				if d.searchMode != DropDownSearchPrefix {
+					d.filter()
+				}
+				setFocus(d)
+			} else {
+				d.prefix = ""
+				d.openList(setFocus)
+			}
+			consumed = true
+		}
+		return
+	})
+}
+
 // Focus is called by the application when the primitive receives focus.
 func (d *DropDown) Focus(delegate func(p Primitive)) {
 	d.Box.Focus(delegate)
@@ -197,3 +197,24 @@ func (f *Flex) HasFocus() bool {
 	}
 	return false
 }
+
+// MouseHandler returns the mouse handler for this primitive.
+func (f *Flex) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return f.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		if !f.InRect(event.Position()) {
+			return false
+		}
+
+		// Pass mouse events along to the first child item that takes it.
+		for index := len(f.items) - 1; index >= 0; index-- {
+			item := f.items[index].Item
+			if item == nil {
+				continue
+			}
+			if item.MouseHandler()(action, event, setFocus) {
+				return true
+			}
+		}
+		return false
+	})
+}
@@ -1,6 +1,11 @@
 package tview
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
 	"github.com/gdamore/tcell"
 )
 
@@ -43,6 +48,115 @@ type FormItem interface {
 	GetID() int
 }
 
+// FormItemValue is implemented by form items whose value can be read and
+// written in a uniform way. It is used by Form.GetValues() and
+// Form.SetValues(). All form items provided by this package implement it:
+//
+//   - InputField, TextArea: The text (a string).
+//   - Checkbox: Whether or not it is checked (a bool).
+//   - DropDown, RadioButtons: The name of the selected option (a string).
+//   - ListBox: The name of the current item (a string) or, in multi-select
+//     mode, the names of the selected items (a []string).
+type FormItemValue interface {
+	// GetValue returns the item's current value.
+	GetValue() interface{}
+
+	// SetValue sets the item's value. An error is returned if the value has
+	// the wrong type or is not allowed.
+	SetValue(value interface{}) error
+}
+
+// formValueText converts a value to the text of a text item.
+func formValueText(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// formItemKey returns the key under which a form item's value is found in
+// Form.GetValues(): Its name, if it has one, or its label otherwise.
+func formItemKey(item FormItem) string {
+	if named, ok := item.(interface{ GetName() string }); ok && named.GetName() != "" {
+		return named.GetName()
+	}
+	return formItemLabel(item)
+}
+
+// formItemLabel returns a form item's label without color tags, label filler
+// placeholders, and surrounding spaces.
+func formItemLabel(item FormItem) string {
+	return strings.TrimSpace(strings.Replace(stripTags(item.GetLabel()), "%s", "", -1))
+}
+
+// Validator checks the value of a form item. Validators are attached to form
+// items with Form.AddValidator().
+type Validator interface {
+	// Validate returns an error describing why the item's current value is
+	// invalid or nil if the value is valid. The error message is shown below
+	// the item.
+	Validate(item FormItem) error
+}
+
+// ValidatorFunc is an adapter which allows the use of ordinary functions as
+// validators.
+type ValidatorFunc func(item FormItem) error
+
+// Validate calls v(item).
+func (v ValidatorFunc) Validate(item FormItem) error {
+	return v(item)
+}
+
+// TextValidator returns a validator for form items which hold text, such as
+// InputField and TextArea. The given function receives the item's text. Items
+// without a GetText() method are always valid.
+func TextValidator(validate func(text string) error) Validator {
+	return ValidatorFunc(func(item FormItem) error {
+		if textItem, ok := item.(interface{ GetText() string }); ok {
+			return validate(textItem.GetText())
+		}
+		return nil
+	})
+}
+
+// ValidationError describes a form item whose value is invalid.
+type ValidationError struct {
+	// The index of the item in the form.
+	Index int
+
+	// The invalid item.
+	Item FormItem
+
+	// The error returned by the item's validator.
+	Err error
+}
+
+// Error returns the item's label followed by the validator's error message.
+func (e *ValidationError) Error() string {
+	label := formItemLabel(e.Item)
+	if label == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s %s", label, e.Err)
+}
+
+// ValidationErrors is a list of validation errors, in the order of the form
+// items.
+type ValidationErrors []*ValidationError
+
+// Error joins the messages of all validation errors.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for index, err := range e {
+		messages[index] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
 // Form allows you to combine multiple one-line form elements into a vertical
 // or horizontal layout. Form elements include types such as InputField or
 // Checkbox. These elements can be optionally followed by one or more buttons
@@ -103,6 +217,34 @@ type Form struct {
 	itemsColumn []int
 
 	columnPadding int
+
+	// The validators attached to form items.
+	validators map[FormItem][]Validator
+
+	// The current validation error messages of form items.
+	itemErrors map[FormItem]error
+
+	// The color of validation error messages.
+	errorColor tcell.Color
+
+	// If set to true, items are validated when the user leaves them.
+	validateOnBlur bool
+
+	// The buttons which validate the form before their selected function is
+	// called.
+	validatingButtons map[*Button]bool
+
+	// An optional function which is called when a validating button was
+	// selected but the form was invalid.
+	validationFailed func(errors ValidationErrors)
+
+	// The values of the form items when they were added or when Snapshot() was
+	// last called.
+	initialValues map[FormItem]interface{}
+
+	// An optional function which is called when the user changed the value of
+	// a form item.
+	changed func(item FormItem)
 }
 
 // NewForm returns a new form.
@@ -121,6 +263,7 @@ func NewForm() *Form {
 		fieldTextColor:        Styles.FieldTextColor,
 		buttonBackgroundColor: Styles.ButtonBackgroundColor,
 		buttonTextColor:       Styles.ButtonTextColor,
+		errorColor:            Styles.ErrorTextColor,
 	}
 
 	f.width = 0
@@ -290,10 +433,149 @@ func (f *Form) AddCheckbox(label string, checked bool, changed func(checked bool
 // AddButton adds a new button to the form. The "selected" function is called
 // when the user selects this button. It may be nil.
 func (f *Form) AddButton(label string, selected func()) *Form {
-	f.buttons = append(f.buttons, NewButton(label).SetSelectedFunc(selected))
+	button := NewButton(label)
+	button.SetSelectedFunc(func() {
+		if f.validatingButtons[button] {
+			if errors := f.Validate(); errors != nil {
+				if f.validationFailed != nil {
+					f.validationFailed(errors)
+				}
+				return
+			}
+		}
+		if selected != nil {
+			selected()
+		}
+	})
+	f.buttons = append(f.buttons, button)
 	return f
 }
 
+// SetButtonValidation sets whether the button at the given index (see
+// GetFormButton()) validates the form before its "selected" function is
+// called. If the form is invalid, the function is not called. See also
+// SetValidationFailedFunc().
+func (f *Form) SetButtonValidation(index int, validate bool) *Form {
+	if index < 0 || index >= len(f.buttons) {
+		return f
+	}
+	if f.validatingButtons == nil {
+		f.validatingButtons = make(map[*Button]bool)
+	}
+	f.validatingButtons[f.buttons[index]] = validate
+	return f
+}
+
+// SetValidationFailedFunc sets a handler which is called when a button that
+// validates the form (see SetButtonValidation()) was selected but the form
+// was invalid. The handler receives the validation errors.
+func (f *Form) SetValidationFailedFunc(handler func(errors ValidationErrors)) *Form {
+	f.validationFailed = handler
+	return f
+}
+
+// AddValidator attaches a validator to a form item. An item may have more
+// than one validator, they are called in the order they were added until one
+// of them fails.
+func (f *Form) AddValidator(item FormItem, validator Validator) *Form {
+	if f.validators == nil {
+		f.validators = make(map[FormItem][]Validator)
+	}
+	f.validators[item] = append(f.validators[item], validator)
+	return f
+}
+
+// SetValidateOnBlur sets whether or not form items are validated when the user
+// leaves them. By default, they are only validated when Validate() is called.
+func (f *Form) SetValidateOnBlur(validate bool) *Form {
+	f.validateOnBlur = validate
+	return f
+}
+
+// SetErrorColor sets the color of validation error messages.
+func (f *Form) SetErrorColor(color tcell.Color) *Form {
+	f.errorColor = color
+	return f
+}
+
+// Validate validates all form items which have validators attached to them
+// and returns the errors, in the order of the items. Nil is returned if all
+// items are valid. Error messages are shown below the invalid items until the
+// items are validated again or the messages are cleared with
+// ClearValidationErrors().
+func (f *Form) Validate() ValidationErrors {
+	var errors ValidationErrors
+	for index, item := range f.items {
+		if err := f.ValidateItem(item); err != nil {
+			errors = append(errors, &ValidationError{
+				Index: index,
+				Item:  item,
+				Err:   err,
+			})
+		}
+	}
+	return errors
+}
+
+// ValidateItem validates a single form item and returns the error of the
+// first validator which failed or nil if the item is valid. The item's error
+// message is updated accordingly.
+func (f *Form) ValidateItem(item FormItem) error {
+	var err error
+	for _, validator := range f.validators[item] {
+		if err = validator.Validate(item); err != nil {
+			break
+		}
+	}
+	f.SetItemError(item, err)
+	return err
+}
+
+// SetItemError sets the error message shown below a form item, e.g. for errors
+// which were detected outside of the form. Provide nil to remove the message.
+func (f *Form) SetItemError(item FormItem, err error) *Form {
+	if err == nil {
+		delete(f.itemErrors, item)
+		return f
+	}
+	if f.itemErrors == nil {
+		f.itemErrors = make(map[FormItem]error)
+	}
+	f.itemErrors[item] = err
+	return f
+}
+
+// GetItemError returns the error message currently shown for a form item or
+// nil if there is none.
+func (f *Form) GetItemError(item FormItem) error {
+	return f.itemErrors[item]
+}
+
+// ClearValidationErrors removes all error messages from the form.
+func (f *Form) ClearValidationErrors() *Form {
+	f.itemErrors = nil
+	return f
+}
+
+// errorRows returns the number of rows needed below a form item for its error
+// message.
+func (f *Form) errorRows(item FormItem) int {
+	if f.itemErrors[item] == nil {
+		return 0
+	}
+	return 1
+}
+
+// itemSpacing returns the number of rows between a form item and the next one
+// in the same column of a vertical layout. Error messages are drawn into the
+// padding between items but if there is not enough of it, rows are added.
+func (f *Form) itemSpacing(item FormItem) int {
+	if rows := f.errorRows(item); rows > f.itemPadding {
+		return rows
+	}
+	return f.itemPadding
+}
+
 // HiddenButton hides button by index
 func (f *Form) HiddenButton(index int, state bool) *Form {
 	if len(f.buttons) > index {
@@ -305,9 +587,19 @@ func (f *Form) HiddenButton(index int, state bool) *Form {
 // Clear removes all input elements from the form, including the buttons if
 // specified.
 func (f *Form) Clear(includeButtons bool) *Form {
+	for _, item := range f.items {
+		if watched, ok := item.(interface{ setEventFuncs(before, after func()) }); ok {
+			watched.setEventFuncs(nil, nil)
+		}
+	}
 	f.items = nil
+	f.itemsColumn = nil
+	f.validators = nil
+	f.itemErrors = nil
+	f.initialValues = nil
 	if includeButtons {
 		f.buttons = nil
+		f.validatingButtons = nil
 	}
 	f.focusedElement = 0
 	return f
@@ -338,9 +630,99 @@ func (f *Form) AddFormItemWithColumn(item FormItem, column int) *Form {
 	f.items = append(f.items, item)
 	f.itemsColumn = append(f.itemsColumn, column)
 	f.lastButton = len(f.items)
+	f.trackItem(item)
+	return f
+}
+
+// trackItem records the initial value of a form item and starts watching it
+// for changes. Only items which implement FormItemValue are tracked.
+func (f *Form) trackItem(item FormItem) {
+	valueItem, ok := item.(FormItemValue)
+	if !ok {
+		return
+	}
+	if f.initialValues == nil {
+		f.initialValues = make(map[FormItem]interface{})
+	}
+	f.initialValues[item] = valueItem.GetValue()
+	if watched, ok := item.(interface{ setEventFuncs(before, after func()) }); ok {
+		// The value is compared before and after each event so that changes
+		// made programmatically in between are not reported.
+		var before interface{}
+		watched.setEventFuncs(func() {
+			before = valueItem.GetValue()
+		}, func() {
+			value := valueItem.GetValue()
+			if reflect.DeepEqual(value, before) {
+				return
+			}
+			before = value
+			if f.changed != nil {
+				f.changed(item)
+			}
+		})
+	}
+}
+
+// SetChangedFunc sets a handler which is called when the user changed the
+// value of any form item. The handler receives the item. Only items which
+// implement FormItemValue are watched. Changes made programmatically (e.g. with
+// SetValues() or Reset()) do not trigger the handler.
+func (f *Form) SetChangedFunc(handler func(item FormItem)) *Form {
+	f.changed = handler
+	return f
+}
+
+// Snapshot records the current values of all form items as their initial
+// values, e.g. after they were saved. Afterwards, IsDirty() returns false and
+// Reset() restores these values.
+func (f *Form) Snapshot() *Form {
+	for item := range f.initialValues {
+		f.initialValues[item] = item.(FormItemValue).GetValue()
+	}
 	return f
 }
 
+// IsDirty returns true if the value of any form item differs from its initial
+// value, i.e. its value when it was added to the form or when Snapshot() was
+// last called.
+func (f *Form) IsDirty() bool {
+	return len(f.GetChangedItems()) > 0
+}
+
+// GetChangedItems returns the form items whose values differ from their
+// initial values (see IsDirty()), in the order in which they were added.
+func (f *Form) GetChangedItems() []FormItem {
+	var changed []FormItem
+	for _, item := range f.items {
+		initial, tracked := f.initialValues[item]
+		if tracked && !reflect.DeepEqual(item.(FormItemValue).GetValue(), initial) {
+			changed = append(changed, item)
+		}
+	}
+	return changed
+}
+
+// Reset restores the initial values of all form items (see IsDirty()). An
+// error is returned if an item rejected its initial value.
+func (f *Form) Reset() error {
+	var failures []string
+	for _, item := range f.items {
+		initial, tracked := f.initialValues[item]
+		if !tracked {
+			continue
+		}
+		valueItem := item.(FormItemValue)
+		if err := valueItem.SetValue(initial); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", formItemKey(item), err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("could not reset form values: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
 // GetFormItem returns the form element at the given position, starting with
 // index 0. Elements are referenced in the order they were added. Buttons are
 // not included.
@@ -367,6 +749,73 @@ func (f *Form) GetFormItemByLabel(label string) FormItem {
 	return nil
 }
 
+// GetFormItemByName returns the first form element with the given name (see
+// e.g. InputField.SetName()). If no such element is found, nil is returned.
+func (f *Form) GetFormItemByName(name string) FormItem {
+	for _, item := range f.items {
+		if named, ok := item.(interface{ GetName() string }); ok && named.GetName() == name {
+			return item
+		}
+	}
+	return nil
+}
+
+// GetValues returns the values of all form items which implement
+// FormItemValue. The values are keyed by the items' names or, for items
+// without a name, by their labels (without color tags and surrounding
+// spaces).
+func (f *Form) GetValues() map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, item := range f.items {
+		if valueItem, ok := item.(FormItemValue); ok {
+			values[formItemKey(item)] = valueItem.GetValue()
+		}
+	}
+	return values
+}
+
+// SetValues sets the values of the form items whose keys (see GetValues())
+// are contained in the given map. Other items remain unchanged, keys without a
+// matching item are ignored. If values could not be set, an error describing
+// all failures is returned but all other values are still set.
+func (f *Form) SetValues(values map[string]interface{}) error {
+	var failures []string
+	for _, item := range f.items {
+		valueItem, ok := item.(FormItemValue)
+		if !ok {
+			continue
+		}
+		key := formItemKey(item)
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		if err := valueItem.SetValue(value); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", key, err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("could not set form values: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// GetValuesJSON returns the values of the form items (see GetValues()) as a
+// JSON object.
+func (f *Form) GetValuesJSON() ([]byte, error) {
+	return json.Marshal(f.GetValues())
+}
+
+// SetValuesJSON sets the values of the form items (see SetValues()) from a
+// JSON object such as the one returned by GetValuesJSON().
+func (f *Form) SetValuesJSON(data []byte) error {
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	return f.SetValues(values)
+}
+
 // SetCancelFunc sets a handler which is called when the user hits the Escape
 // key.
 func (f *Form) SetCancelFunc(callback func()) *Form {
@@ -422,19 +871,23 @@ func (f *Form) getMaxHeightColumn() (height int) {
 	maxColumns := f.getColoumnsCount()
 	maxHeight := make([]int, maxColumns)
 
+	// The last item of a column is followed by its error message, if any, but
+	// not by padding.
+	trailing := make([]int, maxColumns)
+
 	if len(f.items) > 0 {
 		for i := 0; i < len(f.items); i++ {
 			column := f.itemsColumn[i]
 			_, _, _, h := f.items[i].GetRect()
-			maxHeight[column] += h + f.itemPadding
+			maxHeight[column] += h + f.itemSpacing(f.items[i])
+			trailing[column] = f.itemSpacing(f.items[i]) - f.errorRows(f.items[i])
 		}
 
 		for column := 0; column < maxColumns; column++ {
-			if height < maxHeight[column] {
-				height = maxHeight[column]
+			if height < maxHeight[column]-trailing[column] {
+				height = maxHeight[column] - trailing[column]
 			}
 		}
-		height -= f.itemPadding
 	}
 
 	return
@@ -515,6 +968,7 @@ func (f *Form) Draw(screen tcell.Screen) {
 
 	// Calculate positions of form items.
 	positions := make([]struct{ x, y, width, height int }, len(f.items)+len(f.Buttons()))
+	errorX, errorWidth := make([]int, len(f.items)), make([]int, len(f.items))
 	var focusedPosition struct{ x, y, width, height int }
 	for index, item := range f.items {
 		column := f.itemsColumn[index]
@@ -571,11 +1025,22 @@ func (f *Form) Draw(screen tcell.Screen) {
 			focusedPosition = positions[index]
 		}
 
+		// Error messages start below the item's field.
+		errorX[index] = x + leftPadding
+		if labelWidth > 0 {
+			errorX[index] += labelWidth + 1
+		}
+		errorWidth[index] = rightLimit - errorX[index]
+		if f.horizontal {
+			// Don't run into the next item's message.
+			errorWidth[index] = x + itemWidth - errorX[index]
+		}
+
 		// Advance to next item.
 		if f.horizontal {
 			x += itemWidth + f.itemPadding
 		} else {
-			y += positions[index].height + f.itemPadding
+			y += positions[index].height + f.itemSpacing(item)
 		}
 		colY[column] = y
 	}
@@ -668,6 +1133,11 @@ func (f *Form) Draw(screen tcell.Screen) {
 			continue
 		}
 
+		// Draw its error message.
+		if err := f.itemErrors[item]; err != nil && y+height >= topLimit && y+height < bottomLimit {
+			Print(screen, Escape(err.Error()), errorX[index], y+height, errorWidth[index], AlignLeft, f.errorColor)
+		}
+
 		// Draw items with focus last (in case of overlaps).
 		if item.GetFocusable().HasFocus() {
 			defer item.Draw(screen)
@@ -770,6 +1240,9 @@ func (f *Form) Focus(delegate func(p Primitive)) {
 	)
 
 	itemHandler := func(key tcell.Key) {
+		if f.validateOnBlur && key != tcell.KeyEscape && f.focusedElement < len(f.items) {
+			f.ValidateItem(f.items[f.focusedElement])
+		}
 		switch key {
 		case tcell.KeyTab:
 			nextStep(makeRange(0, len(f.items))...)
@@ -839,3 +1312,55 @@ func (f *Form) HasFocus() bool {
 	}
 	return false
 }
+
+// MouseHandler returns the mouse handler for this primitive.
+func (f *Form) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return f.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		x, y := event.Position()
+		if !f.InRect(x, y) {
+			return false
+		}
+
+		// Find the item or button the mouse is over.
+		var (
+			element Primitive
+			index   = -1
+		)
+		for itemIndex, item := range f.items {
+			if item.IsDisable() {
+				continue
+			}
+			rectX, rectY, width, height := item.GetRect()
+			if x >= rectX && x < rectX+width && y >= rectY && y < rectY+height {
+				element, index = item, itemIndex
+				break
+			}
+		}
+		if element == nil {
+			buttons := f.Buttons()
+			for buttonIndex, button := range buttons {
+				if button.IsDisable() || !button.InRect(x, y) {
+					continue
+				}
+				// Buttons are counted in reverse order, see Focus().
+				element, index = button, len(f.items)+len(buttons)-1-buttonIndex
+				break
+			}
+		}
+		if element == nil {
+			// Swallow clicks on the empty area of the form.
+			return action == MouseLeftDown
+		}
+
+		// Focus the element through the form so that navigation keeps working.
+		if action == MouseLeftDown {
+			if f.validateOnBlur && index != f.focusedElement && f.focusedElement < len(f.items) {
+				f.ValidateItem(f.items[f.focusedElement])
+			}
+			f.focusedElement = index
+			setFocus(f)
+			return true
+		}
+		return element.MouseHandler()(action, event, setFocus)
+	})
+}
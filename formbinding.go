@@ -0,0 +1,508 @@
+package tview
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FormBinding connects the fields of a struct to the items of a form. It is
+// created with Form.Bind().
+//
+// Struct fields are configured with a "form" tag which consists of
+// semicolon-separated key=value pairs:
+//
+//   - label: The item's label. Defaults to the field name.
+//   - widget: One of "input", "password", "checkbox", "dropdown", or "radio".
+//     Defaults to "checkbox" for bool fields and "input" for all others.
+//   - options: The options of a "dropdown" or "radio" widget, separated by
+//     "|". String fields receive the text of the selected option, integer
+//     fields its index. An option must be selected.
+//   - width: The field width of an "input" or "password" widget.
+//   - validate: Comma-separated validation rules: "required", "min=n", and
+//     "max=n". For numbers, "min" and "max" limit the value, for strings
+//     their length.
+//
+// For example:
+//
+//	type Config struct {
+//		Host    string `form:"label=Host name;validate=required"`
+//		Port    int    `form:"width=6;validate=min=1,max=65535"`
+//		Mode    string `form:"widget=dropdown;options=Fast|Safe"`
+//		Verbose bool
+//		secret  string // Unexported fields are ignored.
+//		Other   string `form:"-"` // As are fields tagged with "-".
+//	}
+//
+// Supported field types are strings, booleans, and numbers.
+type FormBinding struct {
+	// The form which contains the generated items.
+	form *Form
+
+	// The struct the form is bound to.
+	target reflect.Value
+
+	// The bound struct fields.
+	fields []*boundField
+}
+
+// boundField is a struct field bound to a form item.
+type boundField struct {
+	// The index of the field in the struct.
+	index int
+
+	// The field's widget type.
+	widget string
+
+	// The options of dropdown and radio widgets.
+	options []string
+
+	// The generated form item.
+	item FormItem
+
+	// The validators for the form item. They are attached to it once all
+	// fields were bound.
+	validators []Validator
+}
+
+// Bind generates form items for the fields of the struct which "ptr" points
+// to (see FormBinding for the supported tags), adds them to the form, and
+// populates them with the struct's values. The struct is only updated when
+// FormBinding.Submit() is called. An error is returned if "ptr" is not a
+// pointer to a struct or if a tag is invalid.
+func (f *Form) Bind(ptr interface{}) (*FormBinding, error) {
+	value := reflect.ValueOf(ptr)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("form binding requires a pointer to a struct")
+	}
+	b := &FormBinding{
+		form:   f,
+		target: value.Elem(),
+	}
+
+	structType := b.target.Type()
+	for index := 0; index < structType.NumField(); index++ {
+		structField := structType.Field(index)
+		tag := structField.Tag.Get("form")
+		if structField.PkgPath != "" || tag == "-" {
+			continue // Unexported or skipped.
+		}
+		field, err := b.bindField(index, structField, tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", structField.Name, err)
+		}
+		b.fields = append(b.fields, field)
+	}
+
+	b.Populate()
+	for _, field := range b.fields {
+		f.AddFormItem(field.item)
+		for _, validator := range field.validators {
+			f.AddValidator(field.item, validator)
+		}
+	}
+
+	return b, nil
+}
+
+// bindField creates the form item for a struct field.
+func (b *FormBinding) bindField(index int, structField reflect.StructField, tag string) (*boundField, error) {
+	kind := structField.Type.Kind()
+	if !isBindableKind(kind) {
+		return nil, fmt.Errorf("unsupported type %s", structField.Type)
+	}
+
+	// Parse the tag.
+	field := &boundField{index: index}
+	label := structField.Name
+	var (
+		width int
+		rules []string
+	)
+	for _, pair := range strings.Split(tag, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value := pair, ""
+		if pos := strings.Index(pair, "="); pos >= 0 {
+			key, value = pair[:pos], pair[pos+1:]
+		}
+		switch key {
+		case "label":
+			label = value
+		case "widget":
+			field.widget = value
+		case "options":
+			field.options = strings.Split(value, "|")
+		case "width":
+			var err error
+			if width, err = strconv.Atoi(value); err != nil {
+				return nil, fmt.Errorf("invalid width %q", value)
+			}
+		case "validate":
+			rules = strings.Split(value, ",")
+		default:
+			return nil, fmt.Errorf("unknown tag key %q", key)
+		}
+	}
+	if field.widget == "" {
+		field.widget = "input"
+		if kind == reflect.Bool {
+			field.widget = "checkbox"
+		}
+	}
+
+	// Create the form item.
+	switch field.widget {
+	case "input", "password":
+		if kind == reflect.Bool {
+			return nil, errors.New("bool fields require a checkbox")
+		}
+		input := NewInputField().SetLabel(label).SetFieldWidth(width)
+		if field.widget == "password" {
+			input.SetMaskCharacter('*')
+		}
+		switch {
+		case isIntKind(kind) || isUintKind(kind):
+			input.SetAcceptanceFunc(InputFieldInteger)
+		case isFloatKind(kind):
+			input.SetAcceptanceFunc(InputFieldFloat)
+		}
+		field.item = input
+	case "checkbox":
+		if kind != reflect.Bool {
+			return nil, errors.New("checkboxes require a bool field")
+		}
+		field.item = NewCheckbox().SetLabel(label)
+	case "dropdown", "radio":
+		if len(field.options) == 0 {
+			return nil, fmt.Errorf("%s requires options", field.widget)
+		}
+		if kind != reflect.String && !isIntKind(kind) && !isUintKind(kind) {
+			return nil, fmt.Errorf("%s requires a string or integer field", field.widget)
+		}
+		if field.widget == "dropdown" {
+			dropDown := NewDropDown().SetLabel(label)
+			for _, option := range field.options {
+				dropDown.AddOption(NewDropDownOption(option, option))
+			}
+			field.item = dropDown
+		} else {
+			options := make([]*RadioOption, len(field.options))
+			for optionIndex, option := range field.options {
+				options[optionIndex] = NewRadioOption(option, option)
+			}
+			field.item = NewRadioButtons().SetLabel(label).SetOptions(options)
+		}
+	default:
+		return nil, fmt.Errorf("unknown widget %q", field.widget)
+	}
+
+	// Prepare validators.
+	for _, rule := range rules {
+		validator, err := b.validator(field, kind, strings.TrimSpace(rule))
+		if err != nil {
+			return nil, err
+		}
+		field.validators = append(field.validators, validator)
+	}
+	if kind != reflect.String && kind != reflect.Bool || field.widget == "dropdown" || field.widget == "radio" {
+		// Numbers must always be parseable and fit into the field, an option
+		// must always be selected.
+		field.validators = append(field.validators, ValidatorFunc(func(item FormItem) error {
+			_, err := b.parse(field)
+			return err
+		}))
+	}
+
+	return field, nil
+}
+
+// validator returns the validator for a validation rule.
+func (b *FormBinding) validator(field *boundField, kind reflect.Kind, rule string) (Validator, error) {
+	name, argument := rule, ""
+	if pos := strings.Index(rule, "="); pos >= 0 {
+		name, argument = rule[:pos], rule[pos+1:]
+	}
+
+	switch name {
+	case "required":
+		return ValidatorFunc(func(item FormItem) error {
+			if b.text(field) == "" {
+				return errors.New("is required")
+			}
+			return nil
+		}), nil
+	case "min", "max":
+		limit, err := strconv.ParseFloat(argument, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s rule %q", name, rule)
+		}
+		return ValidatorFunc(func(item FormItem) error {
+			text := b.text(field)
+			if text == "" {
+				return nil // Empty values are handled by "required".
+			}
+			value := float64(len([]rune(text)))
+			if kind != reflect.String {
+				number, err := strconv.ParseFloat(text, 64)
+				if err != nil {
+					return nil // Reported by the parse validator.
+				}
+				value = number
+			}
+			if name == "min" && value >= limit || name == "max" && value <= limit {
+				return nil
+			}
+			bound := "at least"
+			if name == "max" {
+				bound = "at most"
+			}
+			if kind == reflect.String {
+				return fmt.Errorf("must have %s %s characters", bound, argument)
+			}
+			return fmt.Errorf("must be %s %s", bound, argument)
+		}), nil
+	}
+	return nil, fmt.Errorf("unknown validation rule %q", rule)
+}
+
+// text returns the current value of a bound field's item as text. For
+// checkboxes, this is "true" or an empty string.
+func (b *FormBinding) text(field *boundField) string {
+	switch item := field.item.(type) {
+	case *InputField:
+		return item.GetText()
+	case *Checkbox:
+		if item.IsChecked() {
+			return "true"
+		}
+	case *DropDown:
+		_, text := item.GetCurrentOption()
+		return text
+	case *RadioButtons:
+		if element := item.joinElements[item.currentElement]; element.currentOption >= 0 && element.currentOption < len(element.options) {
+			return element.options[element.currentOption].Name
+		}
+	}
+	return ""
+}
+
+// parse converts the current value of a bound field's item to a value of the
+// struct field's type. An error is returned if the value cannot be converted
+// or does not fit into the struct field.
+func (b *FormBinding) parse(field *boundField) (interface{}, error) {
+	fieldType := b.target.Type().Field(field.index).Type
+	kind := fieldType.Kind()
+	text := b.text(field)
+
+	// Options are stored as their text in string fields and as their index in
+	// integer fields.
+	if field.widget == "dropdown" || field.widget == "radio" {
+		for index, option := range field.options {
+			if option != text {
+				continue
+			}
+			if kind == reflect.String {
+				return text, nil
+			}
+			return int64(index), checkRange(fieldType, int64(index))
+		}
+		return nil, errors.New("requires a selection")
+	}
+
+	switch {
+	case kind == reflect.String:
+		return text, nil
+	case kind == reflect.Bool:
+		return text != "", nil
+	case text == "":
+		return int64(0), nil
+	case isIntKind(kind):
+		value, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, errors.New("must be a whole number")
+		}
+		return value, checkRange(fieldType, value)
+	case isUintKind(kind):
+		value, err := strconv.ParseUint(text, 10, 64)
+		if err != nil {
+			return nil, errors.New("must be a positive whole number")
+		}
+		return value, checkRange(fieldType, value)
+	default:
+		value, err := strconv.ParseFloat(text, fieldType.Bits())
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		return value, nil
+	}
+}
+
+// checkRange returns an error if the given integer does not fit into a struct
+// field of the given type.
+func checkRange(fieldType reflect.Type, value interface{}) error {
+	zero := reflect.Zero(fieldType)
+	switch kind := fieldType.Kind(); {
+	case isIntKind(kind):
+		max := int64(^uint64(0) >> uint(65-fieldType.Bits()))
+		if number, ok := value.(int64); !ok || zero.OverflowInt(number) {
+			return fmt.Errorf("must be between %d and %d", -max-1, max)
+		}
+	case isUintKind(kind):
+		max := ^uint64(0) >> uint(64-fieldType.Bits())
+		var overflow bool
+		switch number := value.(type) {
+		case int64:
+			overflow = number < 0 || zero.OverflowUint(uint64(number))
+		case uint64:
+			overflow = zero.OverflowUint(number)
+		}
+		if overflow {
+			return fmt.Errorf("must be between 0 and %d", max)
+		}
+	}
+	return nil
+}
+
+// Submit validates the form and, if it is valid, writes the values of the
+// generated form items back into the struct. The validation errors are
+// returned otherwise and the struct is left unchanged.
+func (b *FormBinding) Submit() error {
+	if invalid := b.form.Validate(); invalid != nil {
+		return invalid
+	}
+
+	// Parse all values first so the struct is not partially updated.
+	values := make([]interface{}, len(b.fields))
+	for index, field := range b.fields {
+		value, err := b.parse(field)
+		if err != nil {
+			// Validate() catches these unless the form's validators were
+			// removed (e.g. with Form.Clear()).
+			b.form.SetItemError(field.item, err)
+			itemIndex := -1
+			for formIndex, item := range b.form.items {
+				if item == field.item {
+					itemIndex = formIndex
+				}
+			}
+			return ValidationErrors{&ValidationError{
+				Index: itemIndex,
+				Item:  field.item,
+				Err:   err,
+			}}
+		}
+		values[index] = value
+	}
+
+	for index, field := range b.fields {
+		target := b.target.Field(field.index)
+		switch value := values[index].(type) {
+		case string:
+			target.SetString(value)
+		case bool:
+			target.SetBool(value)
+		case int64:
+			if isUintKind(target.Kind()) {
+				target.SetUint(uint64(value))
+			} else if isFloatKind(target.Kind()) {
+				target.SetFloat(float64(value))
+			} else {
+				target.SetInt(value)
+			}
+		case uint64:
+			target.SetUint(value)
+		case float64:
+			target.SetFloat(value)
+		}
+	}
+
+	return nil
+}
+
+// Populate sets the values of the generated form items from the struct, e.g.
+// after the struct was changed elsewhere. Validation error messages of the
+// items are removed.
+func (b *FormBinding) Populate() *FormBinding {
+	for _, field := range b.fields {
+		value := b.target.Field(field.index)
+		b.form.SetItemError(field.item, nil)
+
+		// Determine the selected option.
+		option := -1
+		if field.widget == "dropdown" || field.widget == "radio" {
+			switch {
+			case value.Kind() == reflect.String:
+				for index, text := range field.options {
+					if text == value.String() {
+						option = index
+					}
+				}
+			case isUintKind(value.Kind()):
+				option = int(value.Uint())
+			default:
+				option = int(value.Int())
+			}
+			if option >= len(field.options) {
+				option = -1
+			}
+		}
+
+		switch item := field.item.(type) {
+		case *InputField:
+			switch {
+			case value.Kind() == reflect.String:
+				item.SetText(value.String())
+			case isIntKind(value.Kind()):
+				item.SetText(strconv.FormatInt(value.Int(), 10))
+			case isUintKind(value.Kind()):
+				item.SetText(strconv.FormatUint(value.Uint(), 10))
+			default:
+				item.SetText(strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()))
+			}
+		case *Checkbox:
+			item.SetChecked(value.Bool())
+		case *DropDown:
+			item.SetCurrentOption(option)
+		case *RadioButtons:
+			item.SetCurrentOption(option)
+		}
+	}
+	return b
+}
+
+// GetFormItem returns the form item generated for the struct field with the
+// given name or nil if there is no such field.
+func (b *FormBinding) GetFormItem(fieldName string) FormItem {
+	for _, field := range b.fields {
+		if b.target.Type().Field(field.index).Name == fieldName {
+			return field.item
+		}
+	}
+	return nil
+}
+
+// isBindableKind returns whether struct fields of the given kind can be bound
+// to form items.
+func isBindableKind(kind reflect.Kind) bool {
+	return kind == reflect.String || kind == reflect.Bool || isIntKind(kind) || isUintKind(kind) || isFloatKind(kind)
+}
+
+// isIntKind returns whether the given kind is a signed integer.
+func isIntKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+// isUintKind returns whether the given kind is an unsigned integer.
+func isUintKind(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+// isFloatKind returns whether the given kind is a floating-point number.
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
@@ -155,3 +155,24 @@ func (f *Frame) HasFocus() bool {
 	}
 	return false
 }
+
+// MouseHandler returns the mouse handler for this primitive.
+func (f *Frame) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return f.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		if !f.InRect(event.Position()) {
+			return false
+		}
+
+		// Pass mouse events on to the contained primitive.
+		if f.primitive.MouseHandler()(action, event, setFocus) {
+			return true
+		}
+
+		// Clicking on the frame itself focuses the contained primitive.
+		if action == MouseLeftDown {
+			setFocus(f)
+			return true
+		}
+		return false
+	})
+}
@@ -296,6 +296,26 @@ func (g *Grid) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 	})
 }
 
+// MouseHandler returns the mouse handler for this primitive.
+func (g *Grid) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return g.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		if !g.InRect(event.Position()) {
+			return false
+		}
+
+		// Pass mouse events along to the first visible item that takes it.
+		for _, item := range g.items {
+			if !item.visible || item.Item == nil {
+				continue
+			}
+			if item.Item.MouseHandler()(action, event, setFocus) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
 // Draw draws this primitive onto the screen.
 func (g *Grid) Draw(screen tcell.Screen) {
 	g.Box.Draw(screen)
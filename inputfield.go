@@ -3,17 +3,53 @@ package tview
 import (
 	"fmt"
 	"math"
-	"regexp"
 	"strings"
 	"unicode/utf8"
 
 	"github.com/gdamore/tcell"
-	runewidth "github.com/mattn/go-runewidth"
 )
 
+// InputHistoryStore persists the history of an InputField, see
+// InputField.SetHistoryStore(). Implementations are responsible for handling
+// their own errors (e.g. by logging them).
+type InputHistoryStore interface {
+	// LoadHistory returns the stored history entries, oldest first.
+	LoadHistory() []string
+
+	// SaveHistory stores the given history entries, oldest first. It is called
+	// whenever an entry was added to the history.
+	SaveHistory(entries []string)
+}
+
 // InputField is a one-line box (three lines if there is a title) where the
 // user can enter text.
 //
+// Editing works on grapheme clusters (user-perceived characters), so wide
+// characters (e.g. CJK) and emojis consisting of several code points are
+// inserted, deleted, and skipped as a whole. If the text is wider than the
+// input area, it scrolls horizontally to keep the cursor visible. The
+// following keys are supported:
+//
+//   - Left arrow, Right arrow: Move the cursor by one character.
+//   - Ctrl/Alt-Left arrow, Ctrl/Alt-Right arrow, Alt-B, Alt-F: Move the cursor
+//     by one word.
+//   - Home, Ctrl-A: Move the cursor to the start of the text.
+//   - End, Ctrl-E: Move the cursor to the end of the text.
+//   - Backspace, Delete: Delete the character before or after the cursor.
+//   - Ctrl/Alt-Backspace, Ctrl-W: Delete the word before the cursor.
+//   - Ctrl/Alt-Delete, Alt-D: Delete the word after the cursor.
+//   - Ctrl-K: Delete everything after the cursor.
+//   - Ctrl-U: Delete everything before the cursor.
+//
+// If a history is enabled (see SetHistorySize()), the text is added to it
+// when the user presses Enter. The following keys are then also available:
+//
+//   - Up arrow, Down arrow: Move through the history (unless a list of
+//     suggestions is shown, see SetAutocompleteFunc()).
+//   - Ctrl-R: Search the history backwards, starting a new search or moving to
+//     the next older match. Typed characters extend the search text, Enter
+//     accepts the match, Escape or Ctrl-G cancels the search.
+//
 // Use SetMaskCharacter() to hide input from onlookers (e.g. for password
 // input).
 //
@@ -33,6 +69,10 @@ type InputField struct {
 	// The text to be displayed before the input area.
 	label string
 
+	// An optional name which identifies the item in a form, see
+	// Form.GetValues().
+	name string
+
 	// The text to be displayed before the input area.
 	subLabel string
 
@@ -68,8 +108,13 @@ type InputField struct {
 	// possible.
 	fieldWidth int
 
+	// The cursor position as a byte index into the text. It is always at the
+	// start of a grapheme cluster.
 	cursorPosition int
 
+	// The byte index of the first grapheme cluster shown in the input area.
+	offset int
+
 	// A character to mask entered text (useful for password fields). A value of 0
 	// disables masking.
 	maskCharacter rune
@@ -77,6 +122,43 @@ type InputField struct {
 	// An optional function which may reject the last character that was entered.
 	accept func(text string, ch rune) bool
 
+	// An optional function which returns suggestions for the current text.
+	autocomplete func(text string) []string
+
+	// The list of suggestions or nil if no suggestions are shown.
+	autocompleteList *List
+
+	// Whether or not the list of suggestions was drawn the last time the input
+	// field was drawn.
+	suggestionsDrawn bool
+
+	// The maximum number of history entries, 0 if there is no history.
+	historySize int
+
+	// The history entries, oldest first.
+	history []string
+
+	// The index of the history entry currently shown. If it equals the length
+	// of the history, the user is not browsing the history.
+	historyIndex int
+
+	// The text entered before the user started browsing or searching the
+	// history.
+	historyDraft string
+
+	// An optional store which persists the history.
+	historyStore InputHistoryStore
+
+	// Whether or not a reverse history search is in progress.
+	historySearch bool
+
+	// The text searched for in the history.
+	historySearchText string
+
+	// The index of the history entry matching the search or -1 if there is no
+	// match.
+	historySearchIndex int
+
 	// An optional function which is called when the input has changed.
 	changed func(text string)
 
@@ -144,6 +226,30 @@ func (i *InputField) GetLabel() string {
 	return i.label
 }
 
+// SetName sets a name which identifies the item in a form. It is used instead
+// of the label as the key in Form.GetValues() and Form.SetValues().
+func (i *InputField) SetName(name string) *InputField {
+	i.name = name
+	return i
+}
+
+// GetName returns the name set with SetName().
+func (i *InputField) GetName() string {
+	return i.name
+}
+
+// GetValue returns the text of the input field. See FormItemValue.
+func (i *InputField) GetValue() interface{} {
+	return i.text
+}
+
+// SetValue sets the text of the input field. Values which are not strings are
+// formatted with fmt.Sprint(), nil clears the text. See FormItemValue.
+func (i *InputField) SetValue(value interface{}) error {
+	i.SetText(formValueText(value))
+	return nil
+}
+
 // GetLabelWidth returns label width.
 func (i *InputField) GetLabelWidth() int {
 	return StringWidth(strings.Replace(i.subLabel+i.label, "%s", "", -1))
@@ -257,6 +363,186 @@ func (i *InputField) SetAcceptanceFunc(handler func(textToCheck string, lastChar
 	return i
 }
 
+// SetAutocompleteFunc sets a function which returns suggestions for the
+// current text. It is called whenever the user changes the text. If it returns
+// one or more entries, they are shown in a list below the input field. In an
+// application, the list is drawn after all other primitives so that it covers
+// them. The user can navigate the list with the Up and Down keys, accept a
+// suggestion with Enter or Tab (which replaces the text of the input field),
+// or dismiss the list with Escape. Suggestions may contain color tags.
+//
+// Provide nil to turn off autocompletion.
+func (i *InputField) SetAutocompleteFunc(callback func(currentText string) []string) *InputField {
+	i.autocomplete = callback
+	if callback == nil {
+		i.autocompleteList = nil
+	}
+	return i
+}
+
+// Autocomplete invokes the autocomplete callback (if there is one) with the
+// current text. If it returns suggestions, they are shown below the input
+// field, otherwise the list of suggestions is hidden. This is called
+// automatically when the user changes the text but may also be called to
+// show suggestions programmatically.
+func (i *InputField) Autocomplete() *InputField {
+	if i.autocomplete == nil {
+		return i
+	}
+	entries := i.autocomplete(i.text)
+	if len(entries) == 0 {
+		i.autocompleteList = nil
+		return i
+	}
+
+	// Fill the list of suggestions.
+	if i.autocompleteList == nil {
+		i.autocompleteList = NewList().ShowSecondaryText(false)
+		i.autocompleteList.SetMainTextColor(Styles.PrimitiveBackgroundColor).
+			SetSelectedTextColor(Styles.PrimitiveBackgroundColor).
+			SetSelectedBackgroundColor(Styles.MoreContrastBackgroundColor).
+			SetBackgroundColor(Styles.FieldBackgroundColor)
+	}
+	i.autocompleteList.Clear()
+	for _, entry := range entries {
+		i.autocompleteList.AddItem(entry, "", 0, nil)
+	}
+	return i
+}
+
+// acceptSuggestion replaces the text with the suggestion with the given index
+// and hides the list of suggestions.
+func (i *InputField) acceptSuggestion(index int) {
+	if i.autocompleteList == nil || index < 0 || index >= i.autocompleteList.GetItemCount() {
+		return
+	}
+	text, _ := i.autocompleteList.GetItemText(index)
+	i.autocompleteList = nil
+	i.SetText(stripTags(text))
+}
+
+// SetHistorySize enables the input history and sets the maximum number of
+// entries it keeps. Older entries are discarded. A value of 0 disables the
+// history in which case the Up and Down keys finish the input (see
+// SetDoneFunc()).
+func (i *InputField) SetHistorySize(size int) *InputField {
+	i.historySize = size
+	i.trimHistory()
+	return i
+}
+
+// SetHistoryStore sets a store which persists the input history. The history
+// is replaced with (a copy of) the entries loaded from the store and the store
+// is updated whenever an entry is added. Provide nil to stop persisting the
+// history.
+func (i *InputField) SetHistoryStore(store InputHistoryStore) *InputField {
+	i.historyStore = store
+	if store != nil {
+		i.history = append([]string(nil), store.LoadHistory()...)
+		i.trimHistory()
+	}
+	return i
+}
+
+// AddHistory adds an entry to the input history. Empty entries and entries
+// which are the same as the most recent one are ignored. Nothing happens if
+// the history is not enabled.
+func (i *InputField) AddHistory(entry string) *InputField {
+	if i.historySize <= 0 || entry == "" || len(i.history) > 0 && i.history[len(i.history)-1] == entry {
+		return i
+	}
+	i.history = append(i.history, entry)
+	i.trimHistory()
+	if i.historyStore != nil {
+		i.historyStore.SaveHistory(i.GetHistory())
+	}
+	return i
+}
+
+// GetHistory returns a copy of the entries of the input history, oldest first.
+func (i *InputField) GetHistory() []string {
+	return append([]string(nil), i.history...)
+}
+
+// ClearHistory removes all entries from the input history. A history store is
+// not updated.
+func (i *InputField) ClearHistory() *InputField {
+	i.history = nil
+	i.historyIndex = 0
+	return i
+}
+
+// trimHistory discards the oldest history entries if there are too many and
+// stops any browsing of the history.
+func (i *InputField) trimHistory() {
+	if i.historySize > 0 && len(i.history) > i.historySize {
+		i.history = i.history[len(i.history)-i.historySize:]
+	}
+	i.historyIndex = len(i.history)
+	i.historySearch = false
+}
+
+// searchHistory sets historySearchIndex to the most recent history entry
+// which contains the search text and which is not newer than the given index.
+func (i *InputField) searchHistory(from int) {
+	if from >= len(i.history) {
+		from = len(i.history) - 1
+	}
+	for index := from; index >= 0; index-- {
+		if strings.Contains(i.history[index], i.historySearchText) {
+			i.historySearchIndex = index
+			return
+		}
+	}
+	i.historySearchIndex = -1
+}
+
+// historySearchPrompt returns the text shown while searching the history.
+func (i *InputField) historySearchPrompt() (prompt, match string) {
+	if i.historySearchIndex < 0 {
+		return fmt.Sprintf("(failed reverse-i-search)`%s': ", i.historySearchText), ""
+	}
+	return fmt.Sprintf("(reverse-i-search)`%s': ", i.historySearchText), i.history[i.historySearchIndex]
+}
+
+// handleHistorySearch processes a key event during a reverse history search.
+// It returns true if the event was consumed.
+func (i *InputField) handleHistorySearch(event *tcell.EventKey) bool {
+	switch event.Key() {
+	case tcell.KeyCtrlR: // Next older match.
+		if i.historySearchIndex > 0 {
+			i.searchHistory(i.historySearchIndex - 1)
+		}
+		return true
+	case tcell.KeyRune:
+		i.historySearchText += string(event.Rune())
+		from := i.historySearchIndex
+		if from < 0 {
+			from = len(i.history) - 1
+		}
+		i.searchHistory(from)
+		return true
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if i.historySearchText != "" {
+			runes := []rune(i.historySearchText)
+			i.historySearchText = string(runes[:len(runes)-1])
+		}
+		i.searchHistory(len(i.history) - 1)
+		return true
+	case tcell.KeyEscape, tcell.KeyCtrlG: // Cancel.
+		i.historySearch = false
+		return true
+	}
+
+	// Any other key accepts the match.
+	i.historySearch = false
+	if i.historySearchIndex >= 0 {
+		i.text = i.history[i.historySearchIndex]
+		i.cursorPosition = len(i.text)
+	}
+	return event.Key() == tcell.KeyEnter
+}
+
 // SetChangedFunc sets a handler which is called whenever the text of the input
 // field has changed. It receives the current text (after the change).
 func (i *InputField) SetChangedFunc(handler func(text string)) *InputField {
@@ -291,6 +577,7 @@ func (i *InputField) GetFinishedFunc() func(key tcell.Key) {
 // Draw draws this primitive onto the screen.
 func (i *InputField) Draw(screen tcell.Screen) {
 	i.Box.Draw(screen)
+	i.suggestionsDrawn = false
 
 	fieldBackgroundColor := i.fieldBackgroundColor
 	fieldTextColor := i.fieldTextColor
@@ -344,18 +631,6 @@ func (i *InputField) Draw(screen tcell.Screen) {
 		x++
 	}
 
-	text := i.text
-	textWidth := runewidth.StringWidth(text)
-	if i.maskCharacter > 0 {
-		text = strings.Repeat(string(i.maskCharacter), utf8.RuneCountInString(i.text))
-	}
-	if i.cursorPosition < 0 {
-		i.cursorPosition = 0
-	}
-	if i.cursorPosition > textWidth {
-		i.cursorPosition = textWidth
-	}
-
 	// Draw input area.
 	fieldWidth := i.fieldWidth
 	if fieldWidth == 0 {
@@ -366,98 +641,126 @@ func (i *InputField) Draw(screen tcell.Screen) {
 	}
 	fieldStyle := tcell.StyleDefault.Background(fieldBackgroundColor)
 	for index := 0; index < fieldWidth; index++ {
-		if index == i.cursorPosition && !i.disable && i.focus.HasFocus() {
-			continue
-		}
 		screen.SetContent(x+index, y, ' ', nil, fieldStyle)
 	}
 
 	// Draw placeholder text.
-	if text == "" && i.placeholder != "" {
+	if i.text == "" && i.placeholder != "" {
 		Print(screen, i.placeholder, x, y, fieldWidth, AlignLeft, i.placeholderTextColor)
 	}
 
-	fieldWidth-- // We need one cell for the cursor.
-	// Draw entered text.
-	if fieldWidth < textWidth {
-		runes := []rune(text)
-		p := len(runes)
-		if i.cursorPosition-1 < textWidth-fieldWidth {
-			p = i.cursorPosition + fieldWidth
+	// Show the reverse history search instead of the text.
+	if i.historySearch {
+		prompt, match := i.historySearchPrompt()
+		_, promptWidth := Print(screen, Escape(prompt), x, y, fieldWidth, AlignLeft, i.placeholderTextColor)
+		if promptWidth < fieldWidth {
+			Print(screen, Escape(match), x+promptWidth, y, fieldWidth-promptWidth, AlignLeft, fieldTextColor)
 		}
-		for pos := p - 1; pos >= 0; pos-- {
-			ch := runes[pos]
-			w := runewidth.RuneWidth(ch)
-			if fieldWidth-w < 0 {
-				break
-			}
-			_, _, style, _ := screen.GetContent(x+fieldWidth-w, y)
-			style = style.Foreground(fieldTextColor)
-			for w > 0 {
-				fieldWidth--
-				screen.SetContent(x+fieldWidth, y, ch, nil, style)
-				w--
+		if !i.disable && i.focus.HasFocus() {
+			if promptWidth >= fieldWidth {
+				promptWidth = fieldWidth - 1
 			}
+			screen.ShowCursor(x+promptWidth, y)
 		}
-	} else {
-		pos := 0
-		for _, ch := range text {
-			w := runewidth.RuneWidth(ch)
-			_, _, style, _ := screen.GetContent(x+pos, y)
-			style = style.Foreground(fieldTextColor)
-			for w > 0 {
-				screen.SetContent(x+pos, y, ch, nil, style)
-				pos++
-				w--
-			}
+		return
+	}
+
+	// Scroll horizontally so that the cursor is visible. We need one cell for
+	// the cursor.
+	clusters := i.clusters()
+	i.cursorPosition = clusters.snap(i.cursorPosition)
+	if i.offset > i.cursorPosition {
+		i.offset = i.cursorPosition
+	}
+	i.offset = clusters.snap(i.offset)
+	for i.offset < i.cursorPosition && clusters.width(i.offset, i.cursorPosition)+1 > fieldWidth {
+		i.offset = clusters.next(i.offset)
+	}
+	for i.offset > 0 && clusters.width(clusters.previous(i.offset), len(i.text))+1 <= fieldWidth {
+		i.offset = clusters.previous(i.offset)
+	}
+
+	// Draw entered text.
+	textStyle := fieldStyle.Foreground(fieldTextColor)
+	pos := 0
+	for _, cluster := range clusters {
+		if cluster.start < i.offset {
+			continue
+		}
+		if pos+cluster.width > fieldWidth {
+			break
 		}
+		if i.maskCharacter > 0 {
+			screen.SetContent(x+pos, y, i.maskCharacter, nil, textStyle)
+		} else {
+			screen.SetContent(x+pos, y, cluster.runes[0], cluster.runes[1:], textStyle)
+		}
+		pos += cluster.width
 	}
 
 	// Set cursor.
 	if !i.disable && i.focus.HasFocus() {
-		i.setCursor(screen)
+		screen.ShowCursor(x+clusters.width(i.offset, i.cursorPosition), y)
 	}
-}
 
-// setCursor sets the cursor position.
-func (i *InputField) setCursor(screen tcell.Screen) {
-	x := i.x + i.paddingLeft
-	y := i.y + i.paddingTop
-	rightLimit := x + i.width
-	if i.border {
-		x++
-		y++
-		rightLimit -= 2
+	// Draw the list of suggestions.
+	if i.autocompleteList != nil && i.focus.HasFocus() {
+		i.drawSuggestions(screen, x, y, fieldWidth)
 	}
+}
 
-	cursorIndent := i.cursorPosition
-
-	textWidth := runewidth.StringWidth(i.text)
-	if textWidth > i.fieldWidth {
-		overflow := textWidth - (i.fieldWidth - 2)
-		if overflow > textWidth-cursorIndent {
-
+// drawSuggestions draws the list of suggestions below (or, if there is no
+// space, above) the input area which starts at the given position.
+func (i *InputField) drawSuggestions(screen tcell.Screen, x, y, fieldWidth int) {
+	lwidth := fieldWidth
+	for index := 0; index < i.autocompleteList.GetItemCount(); index++ {
+		text, _ := i.autocompleteList.GetItemText(index)
+		if width := StringWidth(text); width > lwidth {
+			lwidth = width
 		}
-		cursorIndent -= overflow
 	}
-
-	if cursorIndent < 0 {
-		cursorIndent = 0
+	swidth, sheight := screen.Size()
+	if x+lwidth > swidth {
+		lwidth = swidth - x
 	}
 
-	if i.fieldWidth > 0 && cursorIndent > i.fieldWidth-1 {
-		cursorIndent = i.fieldWidth - 1
+	// We prefer to drop down but if there is no space, maybe drop up?
+	ly := y + 1
+	lheight := i.autocompleteList.GetItemCount()
+	if ly+lheight >= sheight && ly-2 > lheight-ly {
+		ly = y - lheight
+		if ly < 0 {
+			ly = 0
+		}
 	}
-	if i.labelWidth > 0 {
-		x += i.labelWidth + 1 + cursorIndent
-	} else {
-		x += StringWidth(i.subLabel+i.label) + 1 + cursorIndent
+	if ly+lheight >= sheight {
+		lheight = sheight - ly
 	}
-	if x >= rightLimit {
-		x = rightLimit - 1
+	i.autocompleteList.SetRect(x, ly, lwidth, lheight)
+	i.autocompleteList.Draw(screen)
+	i.suggestionsDrawn = true
+}
+
+// drawOverlay draws the list of suggestions again, at the position where it
+// was last drawn. The application calls it for the focused primitive after
+// the root primitive was drawn so that the list covers primitives drawn after
+// the input field.
+func (i *InputField) drawOverlay(screen tcell.Screen) {
+	if i.suggestionsDrawn && i.autocompleteList != nil {
+		i.autocompleteList.Draw(screen)
 	}
+}
 
-	screen.ShowCursor(x, y)
+// clusters splits the input field's text into grapheme clusters. If the text
+// is masked, each cluster has a width of one.
+func (i *InputField) clusters() textClusters {
+	clusters := splitClusters(i.text)
+	if i.maskCharacter != 0 {
+		for index := range clusters {
+			clusters[index].width = 1
+		}
+	}
+	return clusters
 }
 
 // InputHandler returns the handler for this primitive.
@@ -465,54 +768,159 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 	return i.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		// Trigger changed events.
 		currentText := i.text
+		browsingHistory := false
 		defer func() {
-			if i.text != currentText && i.changed != nil {
-				i.changed(i.text)
+			if i.text != currentText {
+				if i.changed != nil {
+					i.changed(i.text)
+				}
+				if !browsingHistory {
+					i.historyIndex = len(i.history)
+					i.Autocomplete()
+				}
 			}
 		}()
 
+		// Reverse history search.
+		if i.historySearch {
+			if i.handleHistorySearch(event) {
+				browsingHistory = true
+				return
+			}
+		} else if event.Key() == tcell.KeyCtrlR && i.historySize > 0 {
+			i.autocompleteList = nil
+			i.historySearch = true
+			i.historySearchText = ""
+			i.searchHistory(len(i.history) - 1)
+			return
+		}
+
+		// Navigate the list of suggestions.
+		if i.autocompleteList != nil {
+			current, count := i.autocompleteList.GetCurrentItem(), i.autocompleteList.GetItemCount()
+			switch event.Key() {
+			case tcell.KeyDown:
+				i.autocompleteList.SetCurrentItem((current + 1) % count)
+				return
+			case tcell.KeyUp:
+				i.autocompleteList.SetCurrentItem((current + count - 1) % count)
+				return
+			case tcell.KeyEnter, tcell.KeyTab:
+				i.acceptSuggestion(current)
+				currentText = i.text // SetText() already triggered the changed event.
+				return
+			case tcell.KeyEscape:
+				i.autocompleteList = nil
+				return
+			}
+		}
+
+		clusters := i.clusters()
+		i.cursorPosition = clusters.snap(i.cursorPosition)
+
+		// remove deletes the text between the two byte positions, unless the
+		// acceptance function rejects the result.
+		remove := func(from, to int) {
+			if from >= to {
+				return
+			}
+			newText := i.text[:from] + i.text[to:]
+			if i.accept != nil {
+				deleted, _ := utf8.DecodeRuneInString(i.text[from:])
+				if !i.accept(newText, deleted) {
+					return
+				}
+			}
+			i.text = newText
+			i.cursorPosition = from
+		}
+
+		// Ctrl and Alt turn character-wise into word-wise operations.
+		wordWise := event.Modifiers()&(tcell.ModCtrl|tcell.ModAlt) != 0
+
 		// Process key event.
 		switch key := event.Key(); key {
 		case tcell.KeyRune: // Regular character.
-			newText := i.text[0:i.cursorPosition] + string(event.Rune()) + i.text[i.cursorPosition:]
+			if event.Modifiers()&tcell.ModAlt != 0 {
+				// Emacs-style word commands.
+				switch event.Rune() {
+				case 'b':
+					i.cursorPosition = clusters.previousWord(i.cursorPosition)
+				case 'f':
+					i.cursorPosition = clusters.nextWord(i.cursorPosition)
+				case 'd':
+					remove(i.cursorPosition, clusters.nextWord(i.cursorPosition))
+				}
+				break
+			}
+			newText := i.text[:i.cursorPosition] + string(event.Rune()) + i.text[i.cursorPosition:]
 			if i.accept != nil {
 				if !i.accept(newText, event.Rune()) {
 					break
 				}
 			}
-			i.cursorPosition++
+			i.cursorPosition += utf8.RuneLen(event.Rune())
 			i.text = newText
-		case tcell.KeyCtrlU: // Delete all.
-			i.text = ""
-		case tcell.KeyCtrlW: // Delete last word.
-			lastWord := regexp.MustCompile(`\s*\S+\s*$`)
-			i.text = lastWord.ReplaceAllString(i.text, "")
-		case tcell.KeyBackspace, tcell.KeyBackspace2: // Delete last character.
-			if len(i.text) == 0 || i.cursorPosition < 1 {
-				break
+		case tcell.KeyCtrlA: // Move to the start.
+			i.cursorPosition = 0
+		case tcell.KeyCtrlE: // Move to the end.
+			i.cursorPosition = len(i.text)
+		case tcell.KeyCtrlK: // Delete to the end.
+			remove(i.cursorPosition, len(i.text))
+		case tcell.KeyCtrlU: // Delete to the start.
+			remove(0, i.cursorPosition)
+		case tcell.KeyCtrlW: // Delete the word before the cursor.
+			remove(clusters.previousWord(i.cursorPosition), i.cursorPosition)
+		case tcell.KeyBackspace, tcell.KeyBackspace2: // Delete the character before the cursor.
+			if wordWise {
+				remove(clusters.previousWord(i.cursorPosition), i.cursorPosition)
+			} else {
+				remove(clusters.previous(i.cursorPosition), i.cursorPosition)
 			}
-			newText := i.text[0:i.cursorPosition-1] + i.text[i.cursorPosition:]
-			runes := []rune(i.text)
-			if i.accept != nil {
-				if !i.accept(newText, runes[i.cursorPosition-1]) {
-					break
+		case tcell.KeyDelete: // Delete the character after the cursor.
+			if wordWise {
+				remove(i.cursorPosition, clusters.nextWord(i.cursorPosition))
+			} else {
+				remove(i.cursorPosition, clusters.next(i.cursorPosition))
+			}
+		case tcell.KeyUp, tcell.KeyDown:
+			if i.historySize <= 0 {
+				// Without a history, these keys finish the input.
+				if i.done != nil {
+					i.done(key)
 				}
+				if i.finished != nil {
+					i.finished(key)
+				}
+				break
 			}
-			i.cursorPosition--
-			i.text = newText
-		case tcell.KeyDelete:
-			if len(i.text) == 0 || i.cursorPosition < 1 || len(i.text) == i.cursorPosition {
+
+			// Browse the history.
+			index := i.historyIndex
+			if key == tcell.KeyUp && index > 0 {
+				index--
+			} else if key == tcell.KeyDown && index < len(i.history) {
+				index++
+			}
+			if index == i.historyIndex {
 				break
 			}
-			newText := i.text[0:i.cursorPosition] + i.text[i.cursorPosition+1:]
-			runes := []rune(i.text)
-			if i.accept != nil {
-				if !i.accept(newText, runes[i.cursorPosition]) {
-					break
-				}
+			if i.historyIndex == len(i.history) {
+				i.historyDraft = i.text
+			}
+			i.historyIndex = index
+			if index < len(i.history) {
+				i.text = i.history[index]
+			} else {
+				i.text = i.historyDraft
+			}
+			i.cursorPosition = len(i.text)
+			browsingHistory = true
+		case tcell.KeyEnter, tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape: // We're done.
+			if key == tcell.KeyEnter {
+				i.AddHistory(i.text)
+				i.historyIndex = len(i.history)
 			}
-			i.text = newText
-		case tcell.KeyEnter, tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape, tcell.KeyUp, tcell.KeyDown: // We're done.
 			if i.done != nil {
 				i.done(key)
 			}
@@ -520,17 +928,61 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 				i.finished(key)
 			}
 		case tcell.KeyLeft:
-			i.cursorPosition--
+			if wordWise {
+				i.cursorPosition = clusters.previousWord(i.cursorPosition)
+			} else {
+				i.cursorPosition = clusters.previous(i.cursorPosition)
+			}
 		case tcell.KeyRight:
-			i.cursorPosition++
+			if wordWise {
+				i.cursorPosition = clusters.nextWord(i.cursorPosition)
+			} else {
+				i.cursorPosition = clusters.next(i.cursorPosition)
+			}
 		case tcell.KeyHome:
 			i.cursorPosition = 0
 		case tcell.KeyEnd:
-			i.cursorPosition = runewidth.StringWidth(i.text)
+			i.cursorPosition = len(i.text)
 		}
 	})
 }
 
+// MouseHandler returns the mouse handler for this primitive.
+func (i *InputField) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return i.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		if i.disable {
+			return false
+		}
+		x, y := event.Position()
+
+		// Clicking a suggestion accepts it.
+		if i.autocompleteList != nil && i.autocompleteList.InRect(x, y) {
+			switch action {
+			case MouseLeftClick:
+				i.acceptSuggestion(i.autocompleteList.indexAtPoint(x, y))
+			case MouseScrollUp, MouseScrollDown:
+				i.autocompleteList.MouseHandler()(action, event, func(Primitive) {})
+			}
+			return true
+		}
+
+		if !i.InRect(x, y) {
+			return false
+		}
+		if action == MouseLeftDown {
+			setFocus(i)
+			consumed = true
+		}
+		return
+	})
+}
+
+// Blur is called when this primitive loses focus.
+func (i *InputField) Blur() {
+	i.autocompleteList = nil
+	i.Box.Blur()
+}
+
 // Focus is called when this primitive receives focus.
 func (i *InputField) Focus(delegate func(p Primitive)) {
 	if i.disable {
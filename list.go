@@ -26,6 +26,9 @@ type List struct {
 	// The index of the currently selected item.
 	currentItem int
 
+	// The index of the first item shown the last time the list was drawn.
+	offset int
+
 	// Whether or not to show the secondary item texts.
 	showSecondaryText bool
 
@@ -220,20 +223,20 @@ func (l *List) Draw(screen tcell.Screen) {
 	}
 
 	// We want to keep the current selection in view. What is our offset?
-	var offset int
+	l.offset = 0
 	if l.showSecondaryText {
 		if l.currentItem >= height/2 {
-			offset = l.currentItem + 1 - (height / 2)
+			l.offset = l.currentItem + 1 - (height / 2)
 		}
 	} else {
 		if l.currentItem >= height {
-			offset = l.currentItem + 1 - height
+			l.offset = l.currentItem + 1 - height
 		}
 	}
 
 	// Draw the list items.
 	for index, item := range l.items {
-		if index < offset {
+		if index < l.offset {
 			continue
 		}
 
@@ -345,3 +348,74 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 		}
 	})
 }
+
+// indexAtPoint returns the index of the list item found at the given screen
+// position or a negative value if there is no such list item.
+func (l *List) indexAtPoint(x, y int) int {
+	rectX, rectY, width, height := l.GetInnerRect()
+	if x < rectX || x >= rectX+width || y < rectY || y >= rectY+height {
+		return -1
+	}
+
+	index := y - rectY
+	if l.showSecondaryText {
+		index /= 2
+	}
+	index += l.offset
+
+	if index >= len(l.items) {
+		return -1
+	}
+	return index
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return l.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		if !l.InRect(event.Position()) {
+			return false
+		}
+		previousItem := l.currentItem
+
+		// Process mouse event.
+		switch action {
+		case MouseLeftDown:
+			setFocus(l)
+			consumed = true
+		case MouseLeftClick:
+			index := l.indexAtPoint(event.Position())
+			if index >= 0 {
+				l.currentItem = index
+				if l.currentItem != previousItem && l.changed != nil {
+					item := l.items[l.currentItem]
+					l.changed(l.currentItem, item.MainText, item.SecondaryText, item.Shortcut)
+				}
+				previousItem = l.currentItem
+				item := l.items[l.currentItem]
+				if item.Selected != nil {
+					item.Selected()
+				}
+				if l.selected != nil {
+					l.selected(l.currentItem, item.MainText, item.SecondaryText, item.Shortcut)
+				}
+			}
+			consumed = true
+		case MouseScrollUp:
+			if l.currentItem > 0 {
+				l.currentItem--
+			}
+			consumed = true
+		case MouseScrollDown:
+			if l.currentItem < len(l.items)-1 {
+				l.currentItem++
+			}
+			consumed = true
+		}
+
+		if l.currentItem != previousItem && l.currentItem < len(l.items) && l.changed != nil {
+			item := l.items[l.currentItem]
+			l.changed(l.currentItem, item.MainText, item.SecondaryText, item.Shortcut)
+		}
+		return
+	})
+}
@@ -19,10 +19,18 @@ type listBoxItem struct {
 	SecondaryText string // A secondary text to be shown underneath the main text.
 	Shortcut      rune   // The key to select the list item directly, 0 if there is no shortcut.
 	Selected      func() // The optional function which is called when the item is selected.
+	Checked       bool   // Whether or not the item is selected in multi-select mode.
 }
 
 // ListBox displays rows of items, each of which can be selected.
 //
+// In multi-select mode (see SetMultiSelect()), each item is preceded by a
+// checkbox and any number of items can be selected:
+//
+//   - Space: Select or deselect the current item.
+//   - Ctrl-A: Select all items.
+//   - Ctrl-D: Deselect all items.
+//
 // See https://github.com/rivo/tview/wiki/ListBox for an example.
 type ListBox struct {
 	*Box
@@ -37,6 +45,10 @@ type ListBox struct {
 	// The text to be displayed before the input area.
 	label string
 
+	// An optional name which identifies the item in a form, see
+	// Form.GetValues().
+	name string
+
 	// The screen width of the label area. A value of 0 means use the width of
 	// the label text.
 	labelWidth int
@@ -90,6 +102,17 @@ type ListBox struct {
 	// A callback function set by the Form class and called when the user leaves
 	// this form item.
 	finished func(tcell.Key)
+
+	// Whether or not more than one item can be selected.
+	multiSelect bool
+
+	// The maximum number of items which can be selected in multi-select mode,
+	// 0 for no limit.
+	maxSelected int
+
+	// An optional function which is called when the set of selected items
+	// changed in multi-select mode.
+	selectionChanged func(names []string)
 }
 
 // NewListBox returns a new form.
@@ -257,6 +280,127 @@ func (l *ListBox) AddItem(name, title string, selected func()) *ListBox {
 	return l
 }
 
+// SetMultiSelect sets whether or not the list box allows the selection of
+// multiple items. Leaving multi-select mode deselects all items, calling the
+// handler set with SetSelectionChangedFunc() if any were selected.
+func (l *ListBox) SetMultiSelect(multiSelect bool) *ListBox {
+	if !multiSelect {
+		l.SelectNone()
+	}
+	l.multiSelect = multiSelect
+	return l
+}
+
+// IsMultiSelect returns whether or not the list box is in multi-select mode.
+func (l *ListBox) IsMultiSelect() bool {
+	return l.multiSelect
+}
+
+// SetMaxSelected sets the maximum number of items which can be selected in
+// multi-select mode. Once it is reached, further items cannot be selected
+// until others are deselected. A value of 0 means no limit. Items already
+// selected remain selected.
+func (l *ListBox) SetMaxSelected(max int) *ListBox {
+	l.maxSelected = max
+	return l
+}
+
+// SetSelectionChangedFunc sets a handler which is called when the set of
+// selected items changed in multi-select mode. The handler receives the names
+// of the selected items.
+func (l *ListBox) SetSelectionChangedFunc(handler func(names []string)) *ListBox {
+	l.selectionChanged = handler
+	return l
+}
+
+// GetSelectedItemNames returns the names of the items selected in
+// multi-select mode, in the order of the items.
+func (l *ListBox) GetSelectedItemNames() []string {
+	var names []string
+	for _, item := range l.items {
+		if item.Checked {
+			names = append(names, item.Name)
+		}
+	}
+	return names
+}
+
+// SetSelectedItemNames selects the items with the given names and deselects
+// all others. Names without a matching item are ignored, as are items beyond
+// the limit set with SetMaxSelected().
+func (l *ListBox) SetSelectedItemNames(names ...string) *ListBox {
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		selected[name] = true
+	}
+	l.updateSelection(func(index int, item *listBoxItem) bool {
+		return selected[item.Name]
+	})
+	return l
+}
+
+// IsItemSelected returns whether or not the item with the given index is
+// selected in multi-select mode.
+func (l *ListBox) IsItemSelected(index int) bool {
+	return index >= 0 && index < len(l.items) && l.items[index].Checked
+}
+
+// SetItemSelected selects or deselects the item with the given index. An item
+// is not selected if the limit set with SetMaxSelected() was reached.
+func (l *ListBox) SetItemSelected(index int, selected bool) *ListBox {
+	l.updateSelection(func(itemIndex int, item *listBoxItem) bool {
+		if itemIndex == index {
+			return selected
+		}
+		return item.Checked
+	})
+	return l
+}
+
+// SelectAll selects all items, up to the limit set with SetMaxSelected().
+func (l *ListBox) SelectAll() *ListBox {
+	l.updateSelection(func(index int, item *listBoxItem) bool {
+		return true
+	})
+	return l
+}
+
+// SelectNone deselects all items.
+func (l *ListBox) SelectNone() *ListBox {
+	l.updateSelection(func(index int, item *listBoxItem) bool {
+		return false
+	})
+	return l
+}
+
+// updateSelection sets the selection state of each item to the value returned
+// by the given function, respecting the selection limit. Items which are
+// already selected keep their place within the limit. The "selection changed"
+// handler is called if the selection changed.
+func (l *ListBox) updateSelection(selected func(index int, item *listBoxItem) bool) {
+	checked := make([]bool, len(l.items))
+	var count int
+	for index, item := range l.items {
+		if checked[index] = item.Checked && selected(index, item); checked[index] {
+			count++
+		}
+	}
+	var changed bool
+	for index, item := range l.items {
+		if !checked[index] && !item.Checked && selected(index, item) && (l.maxSelected <= 0 || count < l.maxSelected) {
+			checked[index] = true
+			count++
+		}
+		if checked[index] != item.Checked {
+			item.Checked = checked[index]
+			changed = true
+		}
+	}
+	if changed && l.selectionChanged != nil {
+		l.selectionChanged(l.GetSelectedItemNames())
+	}
+}
+
 // Clear removes all items from the list.
 func (l *ListBox) Clear() *ListBox {
 	l.items = nil
@@ -296,6 +440,14 @@ func (l *ListBox) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Make room for the checkboxes, between the shortcuts and the main text.
+	shortcutX := x - 5
+	checkboxWidth := StringWidth(Styles.GraphicsCheckboxUnchecked)
+	if l.multiSelect {
+		x += checkboxWidth + 1
+		width -= checkboxWidth + 1
+	}
+
 	// Draw the list items.
 	for index, item := range l.items {
 		if index < l.offset {
@@ -308,7 +460,17 @@ func (l *ListBox) Draw(screen tcell.Screen) {
 
 		// Shortcuts.
 		if showShortcuts && item.Shortcut != 0 {
-			Print(screen, fmt.Sprintf("(%s)", string(item.Shortcut)), x-5, y, 4, AlignRight, l.shortcutColor)
+			Print(screen, fmt.Sprintf("(%s)", string(item.Shortcut)), shortcutX, y, 4, AlignRight, l.shortcutColor)
+		}
+
+		// Checkbox.
+		if l.multiSelect {
+			checkbox := Styles.GraphicsCheckboxUnchecked
+			if item.Checked {
+				checkbox = Styles.GraphicsCheckboxChecked
+			}
+			checkboxStyle := tcell.StyleDefault.Background(l.fieldBackgroundColor).Foreground(l.fieldTextColor)
+			printWithStyle(screen, checkbox, x-checkboxWidth-1, y, checkboxWidth, AlignLeft, checkboxStyle)
 		}
 
 		// Main text.
@@ -397,8 +559,22 @@ func (l *ListBox) InputHandler() func(event *tcell.EventKey, setFocus func(p Pri
 			if l.finished != nil {
 				l.finished(key)
 			}
+		case tcell.KeyCtrlA:
+			if l.multiSelect {
+				l.SelectAll()
+			}
+		case tcell.KeyCtrlD:
+			if l.multiSelect {
+				l.SelectNone()
+			}
 		case tcell.KeyRune:
 			ch := event.Rune()
+			if ch == ' ' && l.multiSelect {
+				if l.currentItem >= 0 && l.currentItem < len(l.items) {
+					l.SetItemSelected(l.currentItem, !l.items[l.currentItem].Checked)
+				}
+				break
+			}
 			if ch != ' ' {
 				// It's not a space bar. Is it a shortcut?
 				var found bool
@@ -436,6 +612,42 @@ func (l *ListBox) InputHandler() func(event *tcell.EventKey, setFocus func(p Pri
 	})
 }
 
+// MouseHandler returns the mouse handler for this primitive.
+func (l *ListBox) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return l.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		x, y := event.Position()
+		if l.disable || !l.InRect(x, y) {
+			return false
+		}
+
+		// Process mouse event.
+		switch action {
+		case MouseLeftDown:
+			setFocus(l)
+			consumed = true
+		case MouseLeftClick:
+			_, rectY, _, _ := l.GetInnerRect()
+			index := y - rectY
+			if l.showSecondaryText {
+				index /= 2
+			}
+			index += l.offset
+			if index >= 0 && index < len(l.items) && index != l.currentItem {
+				l.currentItem = index
+				if l.changed != nil {
+					item := l.items[l.currentItem]
+					l.changed(l.currentItem, item.MainText, item.SecondaryText, item.Shortcut)
+				}
+			}
+			if l.multiSelect && index >= 0 && index < len(l.items) {
+				l.SetItemSelected(index, !l.items[index].Checked)
+			}
+			consumed = true
+		}
+		return
+	})
+}
+
 // SetFieldAlign sets the input alignment within the inputfield. This must be
 // either AlignLeft, AlignCenter, or AlignRight.
 func (l *ListBox) SetFieldAlign(align int) FormItem {
@@ -497,6 +709,70 @@ func (l *ListBox) GetLabel() string {
 	return l.label
 }
 
+// SetName sets a name which identifies the item in a form. It is used instead
+// of the label as the key in Form.GetValues() and Form.SetValues().
+func (l *ListBox) SetName(name string) *ListBox {
+	l.name = name
+	return l
+}
+
+// GetName returns the name set with SetName().
+func (l *ListBox) GetName() string {
+	return l.name
+}
+
+// GetValue returns the name of the current item or an empty string if the
+// list box is empty. In multi-select mode, the names of the selected items are
+// returned as a []string. See FormItemValue.
+func (l *ListBox) GetValue() interface{} {
+	if l.multiSelect {
+		names := l.GetSelectedItemNames()
+		if names == nil {
+			names = []string{}
+		}
+		return names
+	}
+	return l.GetCurrentItemName()
+}
+
+// SetValue makes the item with the given name the current item. The value
+// must be a string. In multi-select mode, the value must be a list of names
+// ([]string or []interface{} holding strings) of the items to be selected.
+// See FormItemValue.
+func (l *ListBox) SetValue(value interface{}) error {
+	if l.multiSelect {
+		var names []string
+		switch value := value.(type) {
+		case []string:
+			names = value
+		case []interface{}:
+			for _, element := range value {
+				name, ok := element.(string)
+				if !ok {
+					return fmt.Errorf("list box selection must contain strings, got %T", element)
+				}
+				names = append(names, name)
+			}
+		case nil:
+		default:
+			return fmt.Errorf("list box selection must be a list of strings, got %T", value)
+		}
+		l.SetSelectedItemNames(names...)
+		return nil
+	}
+	name, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("list box value must be a string, got %T", value)
+	}
+	for index, item := range l.items {
+		if item.Name == name {
+			l.SetCurrentItem(index)
+			return nil
+		}
+	}
+	return fmt.Errorf("list box has no item %q", name)
+}
+
 // SetFinishedFunc calls SetDoneFunc().
 func (l *ListBox) SetFinishedFunc(handler func(key tcell.Key)) FormItem {
 	l.finished = handler
@@ -106,6 +106,19 @@ func (m *Modal) HasFocus() bool {
 	return m.form.HasFocus()
 }
 
+// MouseHandler returns the mouse handler for this primitive.
+func (m *Modal) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return m.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		// Pass mouse events on to the form.
+		if m.frame.MouseHandler()(action, event, setFocus) {
+			return true
+		}
+
+		// Clicks inside the modal must not reach the primitives behind it.
+		return action == MouseLeftDown && m.InRect(event.Position())
+	})
+}
+
 // Draw draws this primitive onto the screen.
 func (m *Modal) Draw(screen tcell.Screen) {
 	// Calculate the width of this modal.
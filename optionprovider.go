@@ -0,0 +1,138 @@
+package tview
+
+// ProvidedOption is an option supplied by an OptionProvider.
+type ProvidedOption struct {
+	// The name which identifies the option.
+	Name string
+
+	// The text which is displayed.
+	Text string
+
+	// The (optional) group of the option, see DropDownOption and RadioOption.
+	Group string
+
+	// An (optional) secondary text shown next to the option's text.
+	Description string
+
+	// If set to true, the option cannot be selected.
+	Disabled bool
+}
+
+// OptionProvider supplies the options of a DropDown or RadioButtons primitive
+// on demand instead of all at once, see DropDown.SetOptionProvider() and
+// RadioButtons.SetOptionProvider().
+type OptionProvider interface {
+	// LoadOptions returns the options starting at index "offset". If "limit" is
+	// greater than 0, no more than "limit" options are requested. "more" is
+	// true if there are further options after the returned ones.
+	LoadOptions(offset, limit int) (options []*ProvidedOption, more bool, err error)
+}
+
+// OptionProviderFunc is an adapter which allows the use of ordinary functions
+// as option providers.
+type OptionProviderFunc func(offset, limit int) (options []*ProvidedOption, more bool, err error)
+
+// LoadOptions calls p(offset, limit).
+func (p OptionProviderFunc) LoadOptions(offset, limit int) ([]*ProvidedOption, bool, error) {
+	return p(offset, limit)
+}
+
+// DefaultLoadingText is the text shown by primitives while their options are
+// being loaded from an OptionProvider.
+var DefaultLoadingText = "Loading…"
+
+// optionLoader loads options from an OptionProvider, page by page.
+type optionLoader struct {
+	// The provider of the options.
+	provider OptionProvider
+
+	// If not nil, options are loaded in a separate goroutine and handed over
+	// to the primitive through this application's update queue.
+	app *Application
+
+	// The maximum number of options loaded at once, 0 for no limit.
+	pageSize int
+
+	// The number of options loaded so far.
+	offset int
+
+	// Whether or not the first page was requested.
+	started bool
+
+	// Whether or not the provider has more options.
+	more bool
+
+	// Whether or not a page is currently being loaded.
+	loading bool
+
+	// The error returned by the provider when the last page was requested.
+	err error
+
+	// Incremented when the loader is reset so that the results of requests
+	// started before are discarded.
+	generation int
+}
+
+// load requests the next page of options. The "done" function receives the
+// options once they were loaded, on the goroutine which handles the
+// application's events if loading asynchronously. It is also called with no
+// options if the provider returned an error.
+func (l *optionLoader) load(done func(options []*ProvidedOption)) {
+	if l.loading {
+		return
+	}
+	l.started = true
+	l.loading = true
+	l.err = nil
+
+	generation, offset, limit := l.generation, l.offset, l.pageSize
+	apply := func(options []*ProvidedOption, more bool, err error) {
+		if generation != l.generation {
+			return // The loader was reset in the meantime.
+		}
+		l.loading = false
+		if err != nil {
+			l.err = err
+			done(nil)
+			return
+		}
+		l.offset += len(options)
+		l.more = more
+		done(options)
+	}
+
+	if l.app == nil {
+		apply(l.provider.LoadOptions(offset, limit))
+		return
+	}
+	go func() {
+		options, more, err := l.provider.LoadOptions(offset, limit)
+		l.app.QueueUpdateDraw(func() {
+			apply(options, more, err)
+		})
+	}()
+}
+
+// reset discards all loaded options so that they are loaded again when
+// needed.
+func (l *optionLoader) reset() {
+	l.generation++
+	l.offset = 0
+	l.started = false
+	l.more = false
+	l.loading = false
+	l.err = nil
+}
+
+// status returns the text to be shown after the loaded options: The given
+// loading text while a page is being loaded, the provider's error message if
+// loading failed, or an empty string.
+func (l *optionLoader) status(loadingText string) string {
+	switch {
+	case l.loading:
+		return loadingText
+	case l.err != nil:
+		return l.err.Error()
+	}
+	return ""
+}
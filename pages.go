@@ -247,3 +247,24 @@ func (p *Pages) Draw(screen tcell.Screen) {
 		page.Item.Draw(screen)
 	}
 }
+
+// MouseHandler returns the mouse handler for this primitive.
+func (p *Pages) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return p.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		if !p.InRect(event.Position()) {
+			return false
+		}
+
+		// Pass mouse events along to the topmost visible page that takes it.
+		for index := len(p.pages) - 1; index >= 0; index-- {
+			page := p.pages[index]
+			if !page.Visible {
+				continue
+			}
+			if page.Item.MouseHandler()(action, event, setFocus) {
+				return true
+			}
+		}
+		return false
+	})
+}
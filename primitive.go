@@ -34,6 +34,24 @@ type Primitive interface {
 	// Box.WrapInputHandler() so you inherit that functionality.
 	InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive))
 
+	// MouseHandler returns a handler which receives mouse events. It is called
+	// by the Application class if mouse events were enabled with
+	// Application.EnableMouse().
+	//
+	// The handler receives the logical mouse action (see MouseAction), the
+	// original tcell mouse event, and a function that allows it to set the focus
+	// to a different primitive. It returns true if it has consumed the event.
+	// Mouse events which fall outside the primitive's rectangle should usually
+	// not be consumed.
+	//
+	// The Application's Draw() function will be called automatically after a
+	// handler has consumed an event.
+	//
+	// The Box class provides functionality to intercept mouse input. If you
+	// subclass from Box, it is recommended that you wrap your handler using
+	// Box.WrapMouseHandler() so you inherit that functionality.
+	MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool
+
 	// Focus is called by the application when the primitive receives focus.
 	// Implementers may call delegate() to pass the focus on to another primitive.
 	Focus(delegate func(p Primitive))
@@ -17,6 +17,19 @@ import (
 type RadioOption struct {
 	Name  string
 	Title string
+
+	// The (optional) group of the option. A header with the group's name is
+	// shown before the first option of each group.
+	Group string
+
+	// An (optional) secondary text shown after the option's title.
+	Description string
+
+	// If set to true, the option is shown dimmed and cannot be selected.
+	Disabled bool
+
+	// The position and width of the option the last time it was drawn.
+	x, y, width int
 }
 
 // NewRadioOption returns a new option for RadioOption
@@ -47,6 +60,10 @@ type RadioButtons struct {
 	// The text to be displayed before the input area.
 	label string
 
+	// An optional name which identifies the item in a form, see
+	// Form.GetValues().
+	name string
+
 	labelFiller string
 
 	// The label color.
@@ -78,6 +95,10 @@ type RadioButtons struct {
 
 	joinElements   []*RadioButtons
 	currentElement int
+
+	// The element this one was joined to with Join(), nil if there is none.
+	joinParent *RadioButtons
+
 	// An optional function which is called when the user indicated that they
 	// are done selecting options. The key which was pressed is provided (tab,
 	// shift-tab, or escape).
@@ -98,6 +119,12 @@ type RadioButtons struct {
 	changed func(*RadioOption)
 
 	inputHandler func() func(event *tcell.EventKey, setFocus func(p Primitive))
+
+	// If options are supplied by an OptionProvider, this loads them.
+	loader *optionLoader
+
+	// The text shown while options are being loaded.
+	loadingText string
 }
 
 // NewRadioButtons returns a new radio button primitive.
@@ -113,6 +140,7 @@ func NewRadioButtons() *RadioButtons {
 		selectedBackgroundColor: Styles.PrimaryTextColor,
 		align:       AlignLeft,
 		labelFiller: " ",
+		loadingText: DefaultLoadingText,
 	}
 
 	r.focus = r
@@ -126,6 +154,7 @@ func NewRadioButtons() *RadioButtons {
 func (r *RadioButtons) Join(elements ...*RadioButtons) *RadioButtons {
 	for i := 0; i < len(elements); i++ {
 		elements[i].inputHandler = r.inputHandler
+		elements[i].joinParent = r
 		elements[i].id = r.id
 		elements[i].currentOption = -1
 	}
@@ -139,6 +168,141 @@ func (r *RadioButtons) SetOptions(options []*RadioOption) *RadioButtons {
 	return r
 }
 
+// SetOptionProvider replaces all options with the ones supplied by the given
+// provider. The first page of options is requested when the radio buttons are
+// drawn for the first time or when Load() is called. Further pages (see
+// SetOptionPageSize()) are requested when the user navigates past the last
+// loaded option.
+//
+// If an application is provided, options are loaded in a separate goroutine.
+// In the meantime, a placeholder (see SetLoadingText()) is shown. The loaded
+// options are then added through Application.QueueUpdateDraw(). Otherwise,
+// the provider is called directly.
+//
+// Provide nil to stop using a provider. Its options are kept.
+func (r *RadioButtons) SetOptionProvider(provider OptionProvider, app *Application) *RadioButtons {
+	if r.loader != nil {
+		r.loader.reset()
+	}
+	if provider == nil {
+		r.loader = nil
+		return r
+	}
+	r.loader = &optionLoader{
+		provider: provider,
+		app:      app,
+	}
+	r.options = nil
+	return r
+}
+
+// SetOptionPageSize sets the maximum number of options requested from the
+// option provider at once, see SetOptionProvider(). A value of 0 (the
+// default) requests all options at once.
+func (r *RadioButtons) SetOptionPageSize(size int) *RadioButtons {
+	if r.loader != nil {
+		r.loader.pageSize = size
+	}
+	return r
+}
+
+// SetLoadingText sets the text which is shown while options are being loaded
+// from the option provider.
+func (r *RadioButtons) SetLoadingText(text string) *RadioButtons {
+	r.loadingText = text
+	return r
+}
+
+// ReloadOptions discards all options supplied by the option provider so that
+// they are requested again when the radio buttons are drawn the next time.
+func (r *RadioButtons) ReloadOptions() *RadioButtons {
+	if r.loader != nil {
+		r.loader.reset()
+		r.options = nil
+	}
+	return r
+}
+
+// loadOptions requests the next page of options from the option provider.
+func (r *RadioButtons) loadOptions() {
+	r.loader.load(func(options []*ProvidedOption) {
+		for _, option := range options {
+			r.options = append(r.options, &RadioOption{
+				Name:        option.Name,
+				Title:       option.Text,
+				Group:       option.Group,
+				Description: option.Description,
+				Disabled:    option.Disabled,
+			})
+		}
+	})
+}
+
+// Load requests the first page of options from the option provider (see
+// SetOptionProvider()) if this has not happened yet. This happens
+// automatically when the radio buttons are drawn. Call this function to load
+// options earlier, e.g. so that a form can make room for them before they are
+// first drawn.
+func (r *RadioButtons) Load() *RadioButtons {
+	if r.loader != nil && !r.loader.started {
+		r.loadOptions()
+	}
+	return r
+}
+
+// loadStatus returns the text shown after the options while options are
+// being loaded or if loading them failed, or an empty string.
+func (r *RadioButtons) loadStatus() string {
+	if r.loader == nil {
+		return ""
+	}
+	return r.loader.status(r.loadingText)
+}
+
+// groupStart returns whether the option with the given index is the first
+// option of a group, i.e. whether it is preceded by a group header.
+func (r *RadioButtons) groupStart(index int) bool {
+	group := r.options[index].Group
+	return group != "" && (index == 0 || r.options[index-1].Group != group)
+}
+
+// lineCount returns the number of lines taken up by the options and their
+// group headers in the vertical layout.
+func (r *RadioButtons) lineCount() int {
+	count := len(r.options)
+	for index := range r.options {
+		if r.groupStart(index) {
+			count++
+		}
+	}
+	return count
+}
+
+// optionLine returns the text drawn for the option with the given index, not
+// including the description, and the amount of indentation in the vertical
+// layout.
+func (r *RadioButtons) optionLine(index int) (line string, indent int) {
+	option := r.options[index]
+	radioButton := Styles.GraphicsRadioUnchecked // Unchecked.
+	if index == r.currentOption {
+		radioButton = Styles.GraphicsRadioChecked // Checked.
+	}
+	line = fmt.Sprintf(`%s[white] %s`, radioButton, option.Title)
+	if option.Disabled {
+		line = "[::d]" + line
+	}
+	if option.Group != "" && !r.horizontal {
+		indent = 2
+	}
+	return
+}
+
+// isDisabled returns whether the option with the given index exists and is
+// disabled.
+func (r *RadioButtons) isDisabled(index int) bool {
+	return index >= 0 && index < len(r.options) && r.options[index].Disabled
+}
+
 // AddOptions addes options in the specified position
 func (r *RadioButtons) AddOptions(pos int, options ...*RadioOption) *RadioButtons {
 	options = append(options, r.options[pos:]...)
@@ -182,7 +346,10 @@ func (r *RadioButtons) InputHandler() func(event *tcell.EventKey, setFocus func(
 // height.
 func (r *RadioButtons) GetRect() (int, int, int, int) {
 	x, y, width, _ := r.Box.GetRect()
-	optionsCount := len(r.options)
+	optionsCount := r.lineCount()
+	if r.loadStatus() != "" {
+		optionsCount++
+	}
 	if optionsCount == 0 {
 		optionsCount = 1
 	}
@@ -209,16 +376,31 @@ func (r *RadioButtons) GetFieldWidth() int {
 
 	var maxWidth int
 	for i := 0; i < len(r.options); i++ {
-		line := fmt.Sprintf(`%s[white] %s`, Styles.GraphicsRadioUnchecked, r.options[i].Title)
+		line, indent := r.optionLine(i)
+		lineWidth := indent + StringWidth(line)
+		if description := r.options[i].Description; description != "" {
+			lineWidth += 1 + StringWidth(description)
+		}
 		if r.horizontal {
-			maxWidth += StringWidth(line)
+			if r.groupStart(i) {
+				maxWidth += StringWidth(r.options[i].Group) + 2
+			}
+			maxWidth += lineWidth
 			if i < len(r.options)-1 {
 				maxWidth += r.itemPadding + 1
 			}
-		} else if maxWidth < StringWidth(line) {
-			maxWidth = StringWidth(line)
+			continue
+		}
+		if r.groupStart(i) && maxWidth < StringWidth(r.options[i].Group) {
+			maxWidth = StringWidth(r.options[i].Group)
+		}
+		if maxWidth < lineWidth {
+			maxWidth = lineWidth
 		}
 	}
+	if status := r.loadStatus(); status != "" && !r.horizontal && maxWidth < StringWidth(status) {
+		maxWidth = StringWidth(status)
+	}
 
 	return maxWidth
 }
@@ -302,6 +484,53 @@ func (r *RadioButtons) GetLabel() string {
 	return r.label
 }
 
+// SetName sets a name which identifies the item in a form. It is used instead
+// of the label as the key in Form.GetValues() and Form.SetValues().
+func (r *RadioButtons) SetName(name string) *RadioButtons {
+	r.name = name
+	return r
+}
+
+// GetName returns the name set with SetName().
+func (r *RadioButtons) GetName() string {
+	return r.name
+}
+
+// GetValue returns the name of the selected option or an empty string if no
+// option is selected. See FormItemValue.
+func (r *RadioButtons) GetValue() interface{} {
+	element := r.joinElements[r.currentElement]
+	if element.currentOption < 0 || element.currentOption >= len(element.options) {
+		return ""
+	}
+	return element.options[element.currentOption].Name
+}
+
+// SetValue selects the option with the given name. The value must be a
+// string, an empty string selects no option. See FormItemValue.
+func (r *RadioButtons) SetValue(value interface{}) error {
+	name, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("radio button value must be a string, got %T", value)
+	}
+	if name == "" {
+		r.SetCurrentOption(-1)
+		return nil
+	}
+	// Option indices run across all joined radio buttons.
+	offset := 0
+	for _, element := range r.joinElements {
+		for index, option := range element.options {
+			if option.Name == name {
+				r.SetCurrentOption(offset + index)
+				return nil
+			}
+		}
+		offset += len(element.options)
+	}
+	return fmt.Errorf("radio buttons have no option %q", name)
+}
+
 // SetLockColors locks the change of colors by form
 func (r *RadioButtons) SetLockColors(lock bool) *RadioButtons {
 	r.lockColors = lock
@@ -415,6 +644,8 @@ func (r *RadioButtons) Draw(screen tcell.Screen) {
 	r.Box.Draw(screen)
 	x, y, width, height := r.GetInnerRect()
 
+	r.Load()
+
 	rightLimit := x + width
 	if height < 1 || rightLimit <= x {
 		return
@@ -455,49 +686,69 @@ func (r *RadioButtons) Draw(screen tcell.Screen) {
 	}
 	x++
 
-	var lineWidth int
+	var lineWidth, lineIndex int
 	for index, option := range r.options {
-		if index >= height && !r.horizontal {
-			break
-		}
-		radioButton := Styles.GraphicsRadioUnchecked // Unchecked.
-		if index == r.currentOption {
-			radioButton = Styles.GraphicsRadioChecked // Checked.
+		// Draw the group header.
+		if r.groupStart(index) {
+			header := "[::b]" + Escape(option.Group)
+			if r.horizontal {
+				_, headerWidth := Print(screen, header+":", x+lineWidth, y, width, AlignLeft, tcell.ColorWhite)
+				lineWidth += headerWidth + 1
+			} else {
+				if lineIndex >= height {
+					break
+				}
+				Print(screen, header, x, y+(lineIndex*(r.itemPadding+1)), width, AlignLeft, tcell.ColorWhite)
+				lineIndex++
+			}
 		}
 
-		line := fmt.Sprintf(`%s[white] %s`, radioButton, option.Title)
+		if lineIndex >= height && !r.horizontal {
+			break
+		}
+		line, indent := r.optionLine(index)
 		if r.horizontal {
-			Print(screen, line, x+lineWidth, y, width, AlignLeft, tcell.ColorWhite)
+			option.x, option.y = x+lineWidth, y
 		} else {
-			Print(screen, line, x, y+(index*(r.itemPadding+1)), width, AlignLeft, tcell.ColorWhite)
+			option.x, option.y = x+indent, y+(lineIndex*(r.itemPadding+1))
+		}
+		_, option.width = Print(screen, line, option.x, option.y, width, AlignLeft, tcell.ColorWhite)
+		textWidth := option.width
+
+		// Draw the description.
+		if option.Description != "" {
+			_, descriptionWidth := Print(screen, Escape(option.Description), option.x+option.width+1, option.y, width-option.width-1, AlignLeft, r.secondaryTextColor)
+			option.width += 1 + descriptionWidth
 		}
 
 		// Background color of selected text.
 		if r.HasFocus() && index == r.currentOption {
-			textWidth := StringWidth(line)
 			for bx := 0; bx < textWidth && bx < width; bx++ {
-				if r.horizontal {
-					m, c, style, _ := screen.GetContent(x+lineWidth+bx, y)
-					fg, _, _ := style.Decompose()
-					if fg == r.mainTextColor {
-						fg = r.selectedTextColor
-					}
-					style = style.Background(r.selectedBackgroundColor).Foreground(fg)
-					screen.SetContent(x+lineWidth+bx, y, m, c, style)
-				} else {
-					m, c, style, _ := screen.GetContent(x+bx, y+(index*(r.itemPadding+1)))
-					fg, _, _ := style.Decompose()
-					if fg == r.mainTextColor {
-						fg = r.selectedTextColor
-					}
-					style = style.Background(r.selectedBackgroundColor).Foreground(fg)
-					screen.SetContent(x+bx, y+(index*(r.itemPadding+1)), m, c, style)
+				m, c, style, _ := screen.GetContent(option.x+bx, option.y)
+				fg, _, _ := style.Decompose()
+				if fg == r.mainTextColor {
+					fg = r.selectedTextColor
 				}
+				style = style.Background(r.selectedBackgroundColor).Foreground(fg)
+				screen.SetContent(option.x+bx, option.y, m, c, style)
 			}
 		}
 
 		if r.horizontal {
-			lineWidth += StringWidth(line) + (r.itemPadding + 1)
+			lineWidth += option.width + (r.itemPadding + 1)
+		} else {
+			lineIndex++
+		}
+	}
+
+	// Draw the loading status.
+	if status := r.loadStatus(); status != "" {
+		statusX, statusY := x, y+r.lineCount()*(r.itemPadding+1)
+		if r.horizontal {
+			statusX, statusY = x+lineWidth, y
+		}
+		if statusX < rightLimit && statusY < y+height {
+			Print(screen, Escape(status), statusX, statusY, rightLimit-statusX, AlignLeft, r.secondaryTextColor)
 		}
 	}
 }
@@ -508,8 +759,11 @@ func (r *RadioButtons) defaultInputHandler() func(event *tcell.EventKey, setFocu
 		parent := r
 		r = parent.joinElements[parent.currentElement]
 
+		// Moving to another element does not trigger the "changed" event.
+		var switched bool
+
 		var (
-			previous = func() {
+			previousOption = func() bool {
 				r.currentOption--
 				if r.currentOption < 0 {
 					if len(parent.joinElements) > 1 {
@@ -517,19 +771,27 @@ func (r *RadioButtons) defaultInputHandler() func(event *tcell.EventKey, setFocu
 						if parent.currentElement < 0 {
 							parent.currentElement = len(parent.joinElements) - 1
 						}
-						nextElement := parent.joinElements[parent.currentElement]
-						setFocus(nextElement)
-						nextElement.currentOption = len(nextElement.options) - 1
-						return
+						r = parent.joinElements[parent.currentElement]
+						setFocus(r)
+						r.currentOption = len(r.options) - 1
+						switched = true
+						return true
 					}
 					r.currentOption = len(r.options) - 1 - int(math.Mod(float64(len(r.options)), float64(r.currentOption)))
 				}
-				if r.changed != nil {
-					r.changed(r.options[r.currentOption])
-				}
+				return true
 			}
 
-			next = func() {
+			nextOption = func() bool {
+				// Load more options instead of wrapping around.
+				if r.loader != nil && r.currentOption >= len(r.options)-1 && (r.loader.more || r.loader.loading) {
+					if !r.loader.loading {
+						r.loadOptions()
+					}
+					if r.currentOption+1 >= len(r.options) {
+						return false
+					}
+				}
 				r.currentOption++
 				if r.currentOption >= len(r.options) {
 					if len(parent.joinElements) > 1 {
@@ -537,18 +799,62 @@ func (r *RadioButtons) defaultInputHandler() func(event *tcell.EventKey, setFocu
 						if parent.currentElement >= len(parent.joinElements) {
 							parent.currentElement = 0
 						}
-						nextElement := parent.joinElements[parent.currentElement]
-						setFocus(nextElement)
-						nextElement.currentOption = 0
-						return
+						r = parent.joinElements[parent.currentElement]
+						setFocus(r)
+						r.currentOption = 0
+						switched = true
+						return true
 					}
 					r.currentOption = int(math.Mod(float64(len(r.options)), float64(r.currentOption)))
 				}
-				if r.changed != nil {
+				return true
+			}
+
+			// move moves to the previous or next option, skipping disabled
+			// options. If no enabled option can be reached, the selection is
+			// left unchanged.
+			move = func(step func() bool) {
+				start, startElement := r, parent.currentElement
+				startOptions := make([]int, len(parent.joinElements))
+				attempts := len(parent.joinElements)
+				for index, element := range parent.joinElements {
+					startOptions[index] = element.currentOption
+					attempts += len(element.options)
+				}
+				found := false
+				for ; attempts > 0; attempts-- {
+					if !step() {
+						break
+					}
+					if !r.isDisabled(r.currentOption) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					for index, element := range parent.joinElements {
+						element.currentOption = startOptions[index]
+					}
+					parent.currentElement = startElement
+					if r != start {
+						r = start
+						setFocus(r)
+					}
+					return
+				}
+				if !switched && r.changed != nil && r.currentOption >= 0 && r.currentOption < len(r.options) {
 					r.changed(r.options[r.currentOption])
 				}
 			}
 
+			previous = func() {
+				move(previousOption)
+			}
+
+			next = func() {
+				move(nextOption)
+			}
+
 			done = func(key tcell.Key) {
 				if parent.done != nil {
 					parent.done(key)
@@ -590,3 +896,46 @@ func (r *RadioButtons) defaultInputHandler() func(event *tcell.EventKey, setFocu
 		}
 	})
 }
+
+// MouseHandler returns the mouse handler for this primitive.
+func (r *RadioButtons) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return r.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		x, y := event.Position()
+		rectX, rectY, width, height := r.GetRect()
+		if r.disable || x < rectX || x >= rectX+width || y < rectY || y >= rectY+height {
+			return false
+		}
+
+		// Process mouse event.
+		switch action {
+		case MouseLeftDown:
+			setFocus(r)
+			consumed = true
+		case MouseLeftClick:
+			// Options are counted across all joined elements.
+			parent := r
+			if r.joinParent != nil {
+				parent = r.joinParent
+			}
+			offset := 0
+			for _, element := range parent.joinElements {
+				if element == r {
+					break
+				}
+				offset += len(element.options)
+			}
+			for index, option := range r.options {
+				if y == option.y && x >= option.x && x < option.x+option.width {
+					if option.Disabled {
+						break
+					}
+					parent.SetCurrentOption(offset + index)
+					setFocus(r)
+					break
+				}
+			}
+			consumed = true
+		}
+		return
+	})
+}
@@ -27,6 +27,7 @@ var Styles = struct {
 	TertiaryTextColor           tcell.Color // Tertiary text (e.g. subtitles, notes).
 	InverseTextColor            tcell.Color // Text on primary-colored backgrounds.
 	ContrastSecondaryTextColor  tcell.Color // Secondary text on ContrastBackgroundColor-colored backgrounds.
+	ErrorTextColor              tcell.Color // Error messages (e.g. failed form validation).
 
 	// Semigraphical runes.
 	GraphicsHoriBar             rune
@@ -47,6 +48,8 @@ var Styles = struct {
 	GraphicsDbBottomRightCorner rune
 	GraphicsDbBottomLeftCorner  rune
 	GraphicsEllipsis            rune
+	GraphicsSortAscending       rune
+	GraphicsSortDescending      rune
 
 	GraphicsRadioChecked   string
 	GraphicsRadioUnchecked string
@@ -73,6 +76,7 @@ var Styles = struct {
 	TertiaryTextColor:           tcell.ColorGreen,
 	InverseTextColor:            tcell.ColorBlue,
 	ContrastSecondaryTextColor:  tcell.ColorDarkCyan,
+	ErrorTextColor:              tcell.ColorRed,
 
 	GraphicsHoriBar:             '\u2500',
 	GraphicsVertBar:             '\u2502',
@@ -92,6 +96,8 @@ var Styles = struct {
 	GraphicsDbBottomRightCorner: '\u255d',
 	GraphicsDbBottomLeftCorner:  '\u255a',
 	GraphicsEllipsis:            '\u2026',
+	GraphicsSortAscending:       '\u25b2',
+	GraphicsSortDescending:      '\u25bc',
 
 	GraphicsRadioChecked:   "\u25c9",
 	GraphicsRadioUnchecked: "\u25ef",
@@ -39,6 +39,11 @@ type TableCell struct {
 	// If set to true, this cell cannot be selected.
 	NotSelectable bool
 
+	// The number of columns and rows this cell spans. Values of 0 and 1 mean
+	// that the cell occupies only its own column and row. See SetColumnSpan()
+	// and SetRowSpan() for details.
+	ColumnSpan, RowSpan int
+
 	// The position and width of the cell the last time table was drawn.
 	x, y, width int
 }
@@ -132,6 +137,31 @@ func (c *TableCell) SetSelectable(selectable bool) *TableCell {
 	return c
 }
 
+// SetColumnSpan sets the number of columns this cell spans, starting with its
+// own column. The cells it covers are not drawn. Hidden columns are not
+// counted. The text of a spanning cell does not widen its own column. Instead,
+// the spanned columns are widened evenly if the text does not fit into them.
+//
+// A cell in the fixed columns (see Table.SetFixed()) does not span columns
+// which are not fixed and vice versa. Cells do not span across other spanning
+// cells.
+func (c *TableCell) SetColumnSpan(columns int) *TableCell {
+	c.ColumnSpan = columns
+	return c
+}
+
+// SetRowSpan sets the number of rows this cell spans, starting with its own
+// row. The cells it covers are not drawn. Rows hidden by the table's filter
+// are not counted. The cell's text is drawn in its own row.
+//
+// A cell in the fixed rows (see Table.SetFixed()) does not span rows which
+// are not fixed and vice versa. If the table is scrolled such that the cell's
+// own row is no longer drawn, the cells it covered are drawn instead.
+func (c *TableCell) SetRowSpan(rows int) *TableCell {
+	c.RowSpan = rows
+	return c
+}
+
 // GetLastPosition returns the position of the table cell the last time it was
 // drawn on screen. If the cell is not on screen, the return values are
 // undefined.
@@ -158,6 +188,10 @@ func (c *TableCell) GetLastPosition() (x, y, width int) {
 // Columns will use as much horizontal space as they need. You can constrain
 // their size with the MaxWidth parameter of the TableCell type.
 //
+// Cells may span several columns and rows, e.g. for headers which group other
+// columns, see TableCell.SetColumnSpan() and TableCell.SetRowSpan(). A cell
+// spanning several rows or columns is selected as a whole.
+//
 // Fixed Columns
 //
 // You can define fixed rows and rolumns via SetFixed(). They will always stay
@@ -172,6 +206,9 @@ func (c *TableCell) GetLastPosition() (x, y, width int) {
 // set, individual cells can be selected. The "selected" handler set via
 // SetSelectedFunc() is invoked when the user presses Enter on a selection.
 //
+// In multi-select mode (see SetMultiSelect()), the user may additionally
+// select a set of rows, e.g. to act on all of them at once.
+//
 // Navigation
 //
 // If the table extends beyond the available space, it can be navigated with
@@ -185,11 +222,46 @@ func (c *TableCell) GetLastPosition() (x, y, width int) {
 //   - G, end: Move to the bottom.
 //   - Ctrl-F, page down: Move down by one page.
 //   - Ctrl-B, page up: Move up by one page.
+//   - /: Search for cells containing a text. Enter closes the prompt (and
+//     searches the entire table in case typing did not reach a match yet).
+//   - n, N: Move to the next / previous cell containing the search text.
+//
+// If the table is editable (see SetEditable()), Enter opens an editor on top
+// of the selected cell instead.
 //
 // When there is no selection, this affects the entire table (except for fixed
 // rows and columns). When there is a selection, the user moves the selection.
 // The class will attempt to keep the selection from moving out of the screen.
 //
+// Searching and Filtering
+//
+// Cells which contain the search text entered after pressing "/" (or set with
+// SetSearchText()) are highlighted. Rows can be hidden with SetFilterFunc().
+//
+// Sorting
+//
+// Columns can be made sortable via SetSortable() and SetSortFunc(). Clicking
+// on a cell in the fixed rows, or pressing Enter while it is selected, sorts
+// the table by that cell's column. Doing it again reverses the order. The
+// following keys sort the table regardless of what can be selected:
+//
+//   - s: Sort by the next sortable column (the first one if the table is not
+//     sorted yet).
+//   - S: Reverse the order of the sort column.
+//
+// Column Layout
+//
+// Columns can be hidden, reordered, and given a fixed width via
+// SetColumnHidden(), SetColumnOrder(), and SetColumnWidth(). If the columns are
+// adjustable (see SetColumnsAdjustable()), the user may also resize and move
+// them. GetColumnLayout() and SetColumnLayout() save and restore these
+// settings, e.g. across sessions.
+//
+// Exporting
+//
+// Export() writes the table's cells as comma- or tab-separated values, as a
+// Markdown table, or as JSON records, e.g. to copy them elsewhere.
+//
 // Use SetInputCapture() to override or modify keyboard input.
 //
 // See https://github.com/rivo/tview/wiki/Table for an example.
@@ -205,11 +277,8 @@ type Table struct {
 	// If there are no borders, the column separator.
 	separator rune
 
-	// The cells of the table. Rows first, then columns.
-	cells [][]*TableCell
-
-	// The rightmost column in the data set.
-	lastColumn int
+	// The table's data structure.
+	content TableContent
 
 	// The number of fixed rows / columns.
 	fixedRows, fixedColumns int
@@ -231,6 +300,13 @@ type Table struct {
 	// The number of visible rows the last time the table was drawn.
 	visibleRows int
 
+	// The indices of the rows and columns and the widths of the columns the
+	// last time the table was drawn.
+	drawnRows, drawnColumns, drawnWidths []int
+
+	// The cells which covered other cells the last time the table was drawn.
+	drawnSpans [][]tableSpan
+
 	// An optional function which gets called when the user presses Enter on a
 	// selected cell. If entire rows selected, the column value is undefined.
 	// Likewise for entire columns.
@@ -244,6 +320,86 @@ type Table struct {
 	// An optional function which gets called when the user presses Escape, Tab,
 	// or Backtab. Also when the user presses Enter if nothing is selectable.
 	done func(key tcell.Key)
+
+	// If set to true, all columns can be sorted by the user.
+	sortable bool
+
+	// The functions used to sort the table by specific columns.
+	sortFuncs map[int]TableSortFunc
+
+	// The column by which the table was last sorted (-1 if none) and whether
+	// the order was descending.
+	sortColumn     int
+	sortDescending bool
+
+	// An optional function which gets called when the user sorted the table.
+	sorted func(column int, descending bool)
+
+	// An optional function which decides which rows are shown.
+	filter func(row int) bool
+
+	// The text which cells are searched for.
+	searchText string
+
+	// Whether or not the search prompt is shown.
+	searching bool
+
+	// The background color of cells which contain the search text.
+	searchHighlightColor tcell.Color
+
+	// Whether or not cells can be edited in place and the key which starts
+	// editing.
+	editable bool
+	editKey  tcell.Key
+
+	// The form items used to edit the cells of specific columns.
+	editors map[int]FormItem
+
+	// An optional function which gets called when the user changed a cell's
+	// text. It may return false to discard the change.
+	cellEdited func(row, column int, oldText, newText string) bool
+
+	// The form item currently editing a cell (nil if none), the primitive
+	// which receives its key events, the position of the edited cell, and its
+	// text before editing.
+	editor              FormItem
+	editFocus           Primitive
+	editRow, editColumn int
+	editText            string
+
+	// Whether or not multiple rows can be selected and the set of selected
+	// rows.
+	multiSelect  bool
+	selectedRows map[int]bool
+
+	// The row where the user started extending the selection (-1 if none)
+	// and the rows selected before.
+	selectionAnchor int
+	selectionBase   map[int]bool
+
+	// The background color of selected rows.
+	selectedRowsColor tcell.Color
+
+	// An optional function which gets called when the set of selected rows
+	// changed.
+	selectedRowsChanged func(rows []int)
+
+	// Whether or not the user may resize and move columns.
+	columnsAdjustable bool
+
+	// The order in which columns are drawn (nil for their natural order), the
+	// hidden columns, and the widths set for specific columns.
+	columnOrder   []int
+	hiddenColumns map[int]bool
+	columnWidths  map[int]int
+
+	// The column being resized with the mouse (-1 if none), the screen
+	// position of its left edge, and its width before resizing.
+	resizeColumn, resizeX, resizeWidth int
+
+	// An optional function which gets called when the user changed the column
+	// layout.
+	columnLayoutChanged func(layout TableColumnLayout)
 }
 
 // NewTable returns a new table.
@@ -252,14 +408,32 @@ func NewTable() *Table {
 		Box:          NewBox(),
 		bordersColor: Styles.GraphicsColor,
 		separator:    ' ',
-		lastColumn:   -1,
+		content:      &tableDefaultContent{lastColumn: -1},
+		sortColumn:   -1,
+
+		searchHighlightColor: Styles.ContrastBackgroundColor,
+		editKey:              tcell.KeyEnter,
+		selectionAnchor:      -1,
+		selectedRowsColor:    Styles.MoreContrastBackgroundColor,
+		resizeColumn:         -1,
 	}
 }
 
-// Clear removes all table data.
+// SetContent sets a new content type for this table. This allows you to back
+// the table by a data structure of your own, for example one that is not
+// fully held in memory. See TableContent for details. Rows selected in
+// multi-select mode are deselected.
+func (t *Table) SetContent(content TableContent) *Table {
+	t.content = content
+	t.SelectNoRows()
+	return t
+}
+
+// Clear removes all table data. Rows selected in multi-select mode are
+// deselected.
 func (t *Table) Clear() *Table {
-	t.cells = nil
-	t.lastColumn = -1
+	t.content.Clear()
+	t.SelectNoRows()
 	return t
 }
 
@@ -382,20 +556,7 @@ func (t *Table) SetDoneFunc(handler func(key tcell.Key)) *Table {
 //
 // To avoid unnecessary garbage collection, fill columns from left to right.
 func (t *Table) SetCell(row, column int, cell *TableCell) *Table {
-	if row >= len(t.cells) {
-		t.cells = append(t.cells, make([][]*TableCell, row-len(t.cells)+1)...)
-	}
-	rowLen := len(t.cells[row])
-	if column >= rowLen {
-		t.cells[row] = append(t.cells[row], make([]*TableCell, column-rowLen+1)...)
-		for c := rowLen; c < column; c++ {
-			t.cells[row][c] = &TableCell{}
-		}
-	}
-	t.cells[row][column] = cell
-	if column > t.lastColumn {
-		t.lastColumn = column
-	}
+	t.content.SetCell(row, column, cell)
 	return t
 }
 
@@ -409,23 +570,81 @@ func (t *Table) SetCellSimple(row, column int, text string) *Table {
 // TableCell object is always returns but it will be uninitialized if the cell
 // was not previously set.
 func (t *Table) GetCell(row, column int) *TableCell {
-	if row >= len(t.cells) || column >= len(t.cells[row]) {
-		return &TableCell{}
+	cell := t.content.GetCell(row, column)
+	if cell == nil {
+		cell = &TableCell{}
 	}
-	return t.cells[row][column]
+	return cell
+}
+
+// RemoveRow removes the row at the given position from the table. If there is
+// no such row, this has no effect.
+func (t *Table) RemoveRow(row int) *Table {
+	t.content.RemoveRow(row)
+	t.moveSelectedRows(func(selected int) int {
+		if selected == row {
+			return -1
+		} else if selected > row {
+			return selected - 1
+		}
+		return selected
+	})
+	return t
+}
+
+// RemoveColumn removes the column at the given position from the table. If
+// there is no such column, this has no effect.
+func (t *Table) RemoveColumn(column int) *Table {
+	t.content.RemoveColumn(column)
+	t.moveColumns(func(moved int) int {
+		if moved == column {
+			return -1
+		} else if moved > column {
+			return moved - 1
+		}
+		return moved
+	})
+	return t
+}
+
+// InsertRow inserts a row before the row with the given index. Cells on the
+// given row and below will be shifted to the bottom by one row. If "row" is
+// equal or larger than the current number of rows, this function has no
+// effect.
+func (t *Table) InsertRow(row int) *Table {
+	t.content.InsertRow(row)
+	t.moveSelectedRows(func(selected int) int {
+		if selected >= row {
+			return selected + 1
+		}
+		return selected
+	})
+	return t
+}
+
+// InsertColumn inserts a column before the column with the given index. Cells
+// in the given column and to its right will be shifted to the right by one
+// column. Rows that have fewer initialized cells than "column" will remain
+// unchanged.
+func (t *Table) InsertColumn(column int) *Table {
+	t.content.InsertColumn(column)
+	t.moveColumns(func(moved int) int {
+		if moved >= column {
+			return moved + 1
+		}
+		return moved
+	})
+	return t
 }
 
 // GetRowCount returns the number of rows in the table.
 func (t *Table) GetRowCount() int {
-	return len(t.cells)
+	return t.content.GetRowCount()
 }
 
 // GetColumnCount returns the (maximum) number of columns in the table.
 func (t *Table) GetColumnCount() int {
-	if len(t.cells) == 0 {
-		return 0
-	}
-	return t.lastColumn + 1
+	return t.content.GetColumnCount()
 }
 
 // GetPageCount returns quantity of pages
@@ -457,7 +676,7 @@ func (t *Table) ScrollToBeginning() *Table {
 func (t *Table) ScrollToEnd() *Table {
 	t.trackEnd = true
 	t.columnOffset = 0
-	t.rowOffset = len(t.cells)
+	t.rowOffset = t.content.GetRowCount()
 	return t
 }
 
@@ -465,21 +684,29 @@ func (t *Table) ScrollToEnd() *Table {
 func (t *Table) Draw(screen tcell.Screen) {
 	t.Box.Draw(screen)
 
+	// The cell editor is drawn last, on top of everything else.
+	if t.editor != nil {
+		defer t.drawEditor(screen)
+	}
+
 	// What's our available screen space?
 	x, y, width, height := t.GetInnerRect()
+	if t.searching && height > 0 {
+		height--
+		t.drawSearchPrompt(screen, x, y+height, width)
+	}
 	if t.borders {
 		t.visibleRows = height / 2
 	} else {
 		t.visibleRows = height
 	}
 
-	// Return the cell at the specified position (nil if it doesn't exist).
-	getCell := func(row, column int) *TableCell {
-		if row < 0 || column < 0 || row >= len(t.cells) || column >= len(t.cells[row]) {
-			return nil
-		}
-		return t.cells[row][column]
-	}
+	// Only the cells which are drawn are requested from the content. Columns
+	// are drawn in the order of the column layout, see SetColumnOrder().
+	rowCount := t.content.GetRowCount()
+	order := t.columnIndices()
+	lastColumn := len(order) - 1
+	getCell := t.content.GetCell
 
 	// If this cell is not selectable, find the next one.
 	if t.rowsSelectable || t.columnsSelectable {
@@ -489,17 +716,34 @@ func (t *Table) Draw(screen tcell.Screen) {
 		if t.selectedRow < 0 {
 			t.selectedRow = 0
 		}
-		for t.selectedRow < len(t.cells) {
-			cell := getCell(t.selectedRow, t.selectedColumn)
+		position := indexOf(order, t.selectedColumn)
+		if position < 0 && t.hiddenColumns[t.selectedColumn] && lastColumn >= 0 {
+			position = 0 // Hidden columns cannot be selected.
+		}
+		for position >= 0 && t.selectedRow < rowCount {
+			if !t.rowVisible(t.selectedRow) {
+				position = 0
+				t.selectedRow++
+				continue
+			}
+			cell := getCell(t.selectedRow, order[position])
 			if cell == nil || !cell.NotSelectable {
 				break
 			}
-			t.selectedColumn++
-			if t.selectedColumn > t.lastColumn {
-				t.selectedColumn = 0
+			position++
+			if position > lastColumn {
+				position = 0
 				t.selectedRow++
 			}
 		}
+		if position >= 0 {
+			t.selectedColumn = order[position]
+		}
+
+		// Cells covered by other cells cannot be selected.
+		if t.rowsSelectable && t.columnsSelectable {
+			t.selectedRow, t.selectedColumn = t.spanOrigin(t.selectedRow, t.selectedColumn)
+		}
 	}
 
 	// Clamp row offsets.
@@ -508,32 +752,39 @@ func (t *Table) Draw(screen tcell.Screen) {
 			t.rowOffset = t.selectedRow - t.fixedRows
 			t.trackEnd = false
 		}
+		// The number of rows shown up to the selected row.
+		shown := t.fixedRows + t.visibleRowCount(t.fixedRows+t.rowOffset, t.selectedRow, height)
 		if t.borders {
-			if 2*(t.selectedRow+1-t.rowOffset) >= height {
-				t.rowOffset = t.selectedRow + 1 - height/2
+			if 2*shown >= height {
+				t.rowOffset = t.scrollOffset(t.selectedRow, height/2-t.fixedRows)
 				t.trackEnd = false
 			}
 		} else {
-			if t.selectedRow+1-t.rowOffset >= height {
-				t.rowOffset = t.selectedRow + 1 - height
+			if shown >= height {
+				t.rowOffset = t.scrollOffset(t.selectedRow, height-t.fixedRows)
 				t.trackEnd = false
 			}
 		}
 	}
+	fixedRows := t.fixedRows
+	if fixedRows > rowCount {
+		fixedRows = rowCount
+	}
+	remaining := fixedRows + t.visibleRowCount(t.fixedRows+t.rowOffset, rowCount-1, height)
 	if t.borders {
-		if 2*(len(t.cells)-t.rowOffset) < height {
+		if 2*remaining < height {
 			t.trackEnd = true
 		}
 	} else {
-		if len(t.cells)-t.rowOffset < height {
+		if remaining < height {
 			t.trackEnd = true
 		}
 	}
 	if t.trackEnd {
 		if t.borders {
-			t.rowOffset = len(t.cells) - height/2
+			t.rowOffset = t.scrollOffset(rowCount-1, height/2-t.fixedRows)
 		} else {
-			t.rowOffset = len(t.cells) - height
+			t.rowOffset = t.scrollOffset(rowCount-1, height-t.fixedRows)
 		}
 	}
 	if t.rowOffset < 0 {
@@ -542,8 +793,9 @@ func (t *Table) Draw(screen tcell.Screen) {
 
 	// Clamp column offset. (Only left side here. The right side is more
 	// difficult and we'll do it below.)
-	if t.columnsSelectable && t.selectedColumn >= t.fixedColumns && t.selectedColumn < t.fixedColumns+t.columnOffset {
-		t.columnOffset = t.selectedColumn - t.fixedColumns
+	selectedPosition := indexOf(order, t.selectedColumn)
+	if t.columnsSelectable && selectedPosition >= t.fixedColumns && selectedPosition < t.fixedColumns+t.columnOffset {
+		t.columnOffset = selectedPosition - t.fixedColumns
 	}
 	if t.columnOffset < 0 {
 		t.columnOffset = 0
@@ -571,12 +823,15 @@ func (t *Table) Draw(screen tcell.Screen) {
 		tableHeight += rowStep
 		return true
 	}
-	for row := 0; row < t.fixedRows && row < len(t.cells); row++ { // Do the fixed rows first.
+	for row := 0; row < t.fixedRows && row < rowCount; row++ { // Do the fixed rows first.
 		if !indexRow(row) {
 			break
 		}
 	}
-	for row := t.fixedRows + t.rowOffset; row < len(t.cells); row++ { // Then the remaining rows.
+	for row := t.fixedRows + t.rowOffset; row < rowCount; row++ { // Then the remaining rows.
+		if !t.rowVisible(row) {
+			continue
+		}
 		if !indexRow(row) {
 			break
 		}
@@ -584,23 +839,28 @@ func (t *Table) Draw(screen tcell.Screen) {
 	var (
 		skipped, lastTableWidth, expansionTotal int
 		expansions                              []int
+
+		// Cells spanning several columns may widen the columns, see
+		// spanWidths(). "spanWidth" is the width they add so far.
+		hasSpans  bool
+		spanWidth int
 	)
 ColumnLoop:
-	for column := 0; ; column++ {
+	for position := 0; ; position++ {
 		// If we've moved beyond the right border, we stop or skip a column.
-		for tableWidth-1 >= width { // -1 because we include one extra column if the separator falls on the right end of the box.
+		for tableWidth+spanWidth-1 >= width { // -1 because we include one extra column if the separator falls on the right end of the box.
 			// We've moved beyond the available space.
-			if column < t.fixedColumns {
+			if position < t.fixedColumns {
 				break ColumnLoop // We're in the fixed area. We're done.
 			}
 			if !t.columnsSelectable && skipped >= t.columnOffset {
 				break ColumnLoop // There is no selection and we've already reached the offset.
 			}
-			if t.columnsSelectable && t.selectedColumn-skipped == t.fixedColumns {
+			if t.columnsSelectable && selectedPosition-skipped == t.fixedColumns {
 				break ColumnLoop // The selected column reached the leftmost point before disappearing.
 			}
 			if t.columnsSelectable && skipped >= t.columnOffset &&
-				(t.selectedColumn < column && lastTableWidth < width-1 && tableWidth < width-1 || t.selectedColumn < column-1) {
+				(selectedPosition < position && lastTableWidth < width-1 && tableWidth+spanWidth < width-1 || selectedPosition < position-1) {
 				break ColumnLoop // We've skipped as many as requested and the selection is visible.
 			}
 			if len(columns) <= t.fixedColumns {
@@ -614,14 +874,30 @@ ColumnLoop:
 			columns = append(columns[:t.fixedColumns], columns[t.fixedColumns+1:]...)
 			widths = append(widths[:t.fixedColumns], widths[t.fixedColumns+1:]...)
 			expansions = append(expansions[:t.fixedColumns], expansions[t.fixedColumns+1:]...)
+			if hasSpans {
+				spanWidth = t.pendingSpanWidth(rows, columns, widths)
+			}
 		}
 
 		// What's this column's width (without expansion)?
+		if position > lastColumn {
+			break // No more columns.
+		}
+		column := order[position]
 		maxWidth := -1
 		expansion := 0
 		for _, row := range rows {
 			if cell := getCell(row, column); cell != nil {
-				_, _, _, _, cellWidth := decomposeString(cell.Text)
+				if cell.ColumnSpan > 1 {
+					// These cells are considered separately, see
+					// spanWidths().
+					if maxWidth < 0 {
+						maxWidth = 0
+					}
+					hasSpans = true
+					continue
+				}
+				_, _, _, _, cellWidth := decomposeString(t.cellText(row, column, cell))
 				if cell.MaxWidth > 0 && cell.MaxWidth < cellWidth {
 					cellWidth = cell.MaxWidth
 				}
@@ -636,6 +912,9 @@ ColumnLoop:
 		if maxWidth < 0 {
 			break // No more cells found in this column.
 		}
+		if columnWidth, ok := t.columnWidths[column]; ok {
+			maxWidth, expansion = columnWidth, 0 // The width was set with SetColumnWidth().
+		}
 
 		// Store new column info at the end.
 		columns = append(columns, column)
@@ -644,9 +923,20 @@ ColumnLoop:
 		tableWidth += maxWidth + 1
 		expansions = append(expansions, expansion)
 		expansionTotal += expansion
+		if hasSpans {
+			spanWidth = t.pendingSpanWidth(rows, columns, widths)
+		}
 	}
 	t.columnOffset = skipped
 
+	// Determine which cells span several rows or columns and make room for
+	// them.
+	spans := t.cellSpans(rows, columns)
+	tableWidth += t.spanWidths(rows, columns, widths, spans)
+	defer func() {
+		t.drawnRows, t.drawnColumns, t.drawnWidths, t.drawnSpans = rows, columns, widths, spans
+	}()
+
 	// If we have space left, distribute it.
 	if tableWidth < width {
 		toDistribute := width - tableWidth
@@ -665,42 +955,55 @@ ColumnLoop:
 	// Helper function which draws border runes.
 	borderStyle := tcell.StyleDefault.Background(t.backgroundColor).Foreground(t.bordersColor)
 	drawBorder := func(colX, rowY int, ch rune) {
-		screen.SetContent(x+colX, y+rowY, ch, nil, borderStyle)
+		if colX >= 0 && colX < width && rowY >= 0 && rowY < height {
+			PrintJoinedBorder(screen, x+colX, y+rowY, ch, t.bordersColor)
+		}
 	}
 
-	// Draw the cells (and borders).
-	var columnX int
+	// The horizontal positions of the columns' left borders (or separators).
+	columnXs := make([]int, len(columns)+1)
 	if !t.borders {
-		columnX--
+		columnXs[0]--
+	}
+	for columnIndex, columnWidth := range widths {
+		columnXs[columnIndex+1] = columnXs[columnIndex] + columnWidth + 1
 	}
-	for columnIndex, column := range columns {
-		columnWidth := widths[columnIndex]
-		for rowY, row := range rows {
+
+	// Draw the cells (and borders).
+	for rowIndex, row := range rows {
+		for columnIndex, column := range columns {
+			span := spans[rowIndex][columnIndex]
+			if span.rows == 0 {
+				continue // This slot is covered by another cell.
+			}
+			columnX := columnXs[columnIndex]
+			cellWidth := columnXs[columnIndex+span.columns] - columnX - 1
+			rowY := rowIndex
 			if t.borders {
-				// Draw borders.
+				// Draw borders. Neighboring borders are joined.
 				rowY *= 2
-				for pos := 0; pos < columnWidth && columnX+1+pos < width; pos++ {
-					drawBorder(columnX+pos+1, rowY, Styles.GraphicsHoriBar)
+				rightX, bottomY := columnX+cellWidth+1, rowY+2*span.rows
+				for pos := columnX + 1; pos < rightX; pos++ {
+					drawBorder(pos, rowY, Styles.GraphicsHoriBar)
+					drawBorder(pos, bottomY, Styles.GraphicsHoriBar)
 				}
-				ch := Styles.GraphicsCross
-				if columnIndex == 0 {
-					if rowY == 0 {
-						ch = Styles.GraphicsTopLeftCorner
-					} else {
-						ch = Styles.GraphicsLeftT
-					}
-				} else if rowY == 0 {
-					ch = Styles.GraphicsTopT
+				for pos := rowY + 1; pos < bottomY; pos++ {
+					drawBorder(columnX, pos, Styles.GraphicsVertBar)
+					drawBorder(rightX, pos, Styles.GraphicsVertBar)
 				}
-				drawBorder(columnX, rowY, ch)
+				drawBorder(columnX, rowY, Styles.GraphicsTopLeftCorner)
+				drawBorder(rightX, rowY, Styles.GraphicsTopRightCorner)
+				drawBorder(columnX, bottomY, Styles.GraphicsBottomLeftCorner)
+				drawBorder(rightX, bottomY, Styles.GraphicsBottomRightCorner)
 				rowY++
 				if rowY >= height {
-					break // No space for the text anymore.
+					continue // No space for the text anymore.
+				}
+			} else if columnIndex > 0 && columnX < width {
+				// Draw separators.
+				for pos := rowY; pos < rowY+span.rows && pos < height; pos++ {
+					screen.SetContent(x+columnX, y+pos, t.separator, nil, borderStyle)
 				}
-				drawBorder(columnX, rowY, Styles.GraphicsVertBar)
-			} else if columnIndex > 0 {
-				// Draw separator.
-				drawBorder(columnX, rowY, t.separator)
 			}
 
 			// Get the cell.
@@ -710,49 +1013,18 @@ ColumnLoop:
 			}
 
 			// Draw text.
-			finalWidth := columnWidth
-			if columnX+1+columnWidth >= width {
+			finalWidth := cellWidth
+			if columnX+1+cellWidth >= width {
 				finalWidth = width - columnX - 1
 			}
 			cell.x, cell.y, cell.width = x+columnX+1, y+rowY, finalWidth
-			_, printed := printWithStyle(screen, cell.Text, x+columnX+1, y+rowY, finalWidth, cell.Align, tcell.StyleDefault.Foreground(cell.Color)|tcell.Style(cell.Attributes))
-			if StringWidth(cell.Text)-printed > 0 && printed > 0 {
+			text := t.cellText(row, column, cell)
+			_, printed := printWithStyle(screen, text, x+columnX+1, y+rowY, finalWidth, cell.Align, tcell.StyleDefault.Foreground(cell.Color)|tcell.Style(cell.Attributes))
+			if StringWidth(text)-printed > 0 && printed > 0 {
 				_, _, style, _ := screen.GetContent(x+columnX+1+finalWidth-1, y+rowY)
 				printWithStyle(screen, string(Styles.GraphicsEllipsis), x+columnX+1+finalWidth-1, y+rowY, 1, AlignLeft, style)
 			}
 		}
-
-		// Draw bottom border.
-		if rowY := 2 * len(rows); t.borders && rowY < height {
-			for pos := 0; pos < columnWidth && columnX+1+pos < width; pos++ {
-				drawBorder(columnX+pos+1, rowY, Styles.GraphicsHoriBar)
-			}
-			ch := Styles.GraphicsBottomT
-			if columnIndex == 0 {
-				ch = Styles.GraphicsBottomLeftCorner
-			}
-			drawBorder(columnX, rowY, ch)
-		}
-
-		columnX += columnWidth + 1
-	}
-
-	// Draw right border.
-	if t.borders && len(t.cells) > 0 && columnX < width {
-		for rowY := range rows {
-			rowY *= 2
-			if rowY+1 < height {
-				drawBorder(columnX, rowY+1, Styles.GraphicsVertBar)
-			}
-			ch := Styles.GraphicsRightT
-			if rowY == 0 {
-				ch = Styles.GraphicsTopRightCorner
-			}
-			drawBorder(columnX, rowY, ch)
-		}
-		if rowY := 2 * len(rows); rowY < height {
-			drawBorder(columnX, rowY, Styles.GraphicsBottomRightCorner)
-		}
 	}
 
 	// Helper function which colors the background of a box.
@@ -788,25 +1060,41 @@ ColumnLoop:
 		selected   bool
 	})
 	var backgroundColors []tcell.Color
-	for rowY, row := range rows {
-		columnX := 0
-		rowSelected := t.rowsSelectable && !t.columnsSelectable && row == t.selectedRow
+	for rowIndex, row := range rows {
 		for columnIndex, column := range columns {
-			columnWidth := widths[columnIndex]
+			span := spans[rowIndex][columnIndex]
+			if span.rows == 0 {
+				continue // This slot is covered by another cell.
+			}
 			cell := getCell(row, column)
 			if cell == nil {
 				continue
 			}
-			bx, by, bw, bh := x+columnX, y+rowY, columnWidth+1, 1
+			columnX := columnXs[columnIndex]
+			cellWidth := columnXs[columnIndex+span.columns] - columnX - 1
+			bx, by, bw, bh := x+columnX+1, y+rowIndex, cellWidth+1, span.rows
 			if t.borders {
-				by = y + rowY*2
+				bx--
+				by = y + rowIndex*2
 				bw++
-				bh = 3
+				bh = 2*span.rows + 1
+			}
+
+			// A cell spanning several rows or columns is selected as a whole.
+			coversRow := indexOf(rows[rowIndex:rowIndex+span.rows], t.selectedRow) >= 0
+			coversColumn := indexOf(columns[columnIndex:columnIndex+span.columns], t.selectedColumn) >= 0
+			rowSelected := t.rowsSelectable && !t.columnsSelectable && coversRow
+			columnSelected := t.columnsSelectable && !t.rowsSelectable && coversColumn
+			cellSelected := !cell.NotSelectable && (columnSelected || rowSelected || t.rowsSelectable && t.columnsSelectable && coversColumn && coversRow)
+			backgroundColor := cell.BackgroundColor
+			if t.selectedRows[row] && !cellSelected {
+				backgroundColor = t.selectedRowsColor
+			}
+			if t.cellMatches(cell) {
+				backgroundColor = t.searchHighlightColor
 			}
-			columnSelected := t.columnsSelectable && !t.rowsSelectable && column == t.selectedColumn
-			cellSelected := !cell.NotSelectable && (columnSelected || rowSelected || t.rowsSelectable && t.columnsSelectable && column == t.selectedColumn && row == t.selectedRow)
-			entries, ok := cellsByBackgroundColor[cell.BackgroundColor]
-			cellsByBackgroundColor[cell.BackgroundColor] = append(entries, &struct {
+			entries, ok := cellsByBackgroundColor[backgroundColor]
+			cellsByBackgroundColor[backgroundColor] = append(entries, &struct {
 				x, y, w, h int
 				text       tcell.Color
 				selected   bool
@@ -819,9 +1107,8 @@ ColumnLoop:
 				selected: cellSelected,
 			})
 			if !ok {
-				backgroundColors = append(backgroundColors, cell.BackgroundColor)
+				backgroundColors = append(backgroundColors, backgroundColor)
 			}
-			columnX += columnWidth + 1
 		}
 	}
 	sort.Slice(backgroundColors, func(i int, j int) bool {
@@ -851,6 +1138,26 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		key := event.Key()
 
+		// The cell editor receives all keys while it is open.
+		if t.editor != nil {
+			t.editInput(event)
+			return
+		}
+
+		// The search prompt receives all keys while it is shown.
+		if t.searching {
+			previouslySelectedRow, previouslySelectedColumn := t.selectedRow, t.selectedColumn
+			t.searchInput(event)
+			t.selectionMoved(previouslySelectedRow, previouslySelectedColumn)
+			return
+		}
+
+		// Edit the selected cell.
+		if t.editable && key == t.editKey && t.rowsSelectable && t.columnsSelectable && t.selectedRow >= t.fixedRows {
+			t.EditCell(t.selectedRow, t.selectedColumn)
+			return
+		}
+
 		if (!t.rowsSelectable && !t.columnsSelectable && key == tcell.KeyEnter) ||
 			key == tcell.KeyEscape ||
 			key == tcell.KeyTab ||
@@ -861,58 +1168,90 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 			return
 		}
 
-		// Movement functions.
+		// Resize or move the selected column.
+		if t.columnsAdjustable && t.columnsSelectable && key == tcell.KeyRune && t.columnInput(event.Rune()) {
+			return
+		}
+
+		// Movement functions. Columns are navigated by their position in the
+		// column layout.
 		previouslySelectedRow, previouslySelectedColumn := t.selectedRow, t.selectedColumn
+		rowCount := t.content.GetRowCount()
+		order := t.columnIndices()
+		lastColumn := len(order) - 1
+		position := indexOf(order, t.selectedColumn)
+		previousPosition := position
+		spanning := t.rowsSelectable && t.columnsSelectable && position >= 0 && position <= lastColumn
 		var (
-			getCell = func(row, column int) *TableCell {
-				if row < 0 || column < 0 || row >= len(t.cells) || column >= len(t.cells[row]) {
+			getCell = func(row, position int) *TableCell {
+				if position < 0 || position > lastColumn {
 					return nil
 				}
-				return t.cells[row][column]
+				return t.content.GetCell(row, order[position])
+			}
+
+			// The last row and column position covered by the selected cell.
+			// Cells spanning several rows or columns are skipped as a whole.
+			spanEnd = func() (int, int) {
+				if !spanning {
+					return t.selectedRow, position
+				}
+				row, column := t.spanEnd(t.selectedRow, order[position])
+				return row, indexOf(order, column)
 			}
 
 			previous = func() {
 				for t.selectedRow >= 0 {
-					cell := getCell(t.selectedRow, t.selectedColumn)
+					if !t.rowVisible(t.selectedRow) {
+						position = lastColumn
+						t.selectedRow--
+						continue
+					}
+					cell := getCell(t.selectedRow, position)
 					if cell == nil || !cell.NotSelectable {
 						return
 					}
-					t.selectedColumn--
-					if t.selectedColumn < 0 {
-						t.selectedColumn = t.lastColumn
+					position--
+					if position < 0 {
+						position = lastColumn
 						t.selectedRow--
 					}
 				}
 			}
 
 			next = func() {
-				if t.selectedColumn > t.lastColumn {
-					t.selectedColumn = 0
+				if position > lastColumn {
+					position = 0
 					t.selectedRow++
-					if t.selectedRow >= len(t.cells) {
-						t.selectedRow = len(t.cells) - 1
+					if t.selectedRow >= rowCount {
+						t.selectedRow = rowCount - 1
 					}
 				}
-				for t.selectedRow < len(t.cells) {
-					cell := getCell(t.selectedRow, t.selectedColumn)
+				for t.selectedRow < rowCount {
+					if !t.rowVisible(t.selectedRow) {
+						position = 0
+						t.selectedRow++
+						continue
+					}
+					cell := getCell(t.selectedRow, position)
 					if cell == nil || !cell.NotSelectable {
 						return
 					}
-					t.selectedColumn++
-					if t.selectedColumn > t.lastColumn {
-						t.selectedColumn = 0
+					position++
+					if position > lastColumn {
+						position = 0
 						t.selectedRow++
 					}
 				}
-				t.selectedColumn = t.lastColumn
-				t.selectedRow = len(t.cells) - 1
+				position = lastColumn
+				t.selectedRow = rowCount - 1
 				previous()
 			}
 
 			home = func() {
 				if t.rowsSelectable {
 					t.selectedRow = 0
-					t.selectedColumn = 0
+					position = 0
 					next()
 				} else {
 					t.trackEnd = false
@@ -923,8 +1262,8 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 
 			end = func() {
 				if t.rowsSelectable {
-					t.selectedRow = len(t.cells) - 1
-					t.selectedColumn = t.lastColumn
+					t.selectedRow = rowCount - 1
+					position = lastColumn
 					previous()
 				} else {
 					t.trackEnd = true
@@ -934,13 +1273,14 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 
 			down = func() {
 				if t.rowsSelectable {
+					t.selectedRow, _ = spanEnd()
 					t.selectedRow++
-					if t.selectedRow >= len(t.cells) {
-						t.selectedRow = len(t.cells) - 1
+					if t.selectedRow >= rowCount {
+						t.selectedRow = rowCount - 1
 					}
 					next()
 				} else {
-					t.rowOffset++
+					t.scrollBy(1)
 				}
 			}
 
@@ -953,15 +1293,15 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 					previous()
 				} else {
 					t.trackEnd = false
-					t.rowOffset--
+					t.scrollBy(-1)
 				}
 			}
 
 			left = func() {
 				if t.columnsSelectable {
-					t.selectedColumn--
-					if t.selectedColumn < 0 {
-						t.selectedColumn = 0
+					position--
+					if position < 0 {
+						position = 0
 					}
 					previous()
 				} else {
@@ -971,9 +1311,10 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 
 			right = func() {
 				if t.columnsSelectable {
-					t.selectedColumn++
-					if t.selectedColumn > t.lastColumn {
-						t.selectedColumn = t.lastColumn
+					_, position = spanEnd()
+					position++
+					if position > lastColumn {
+						position = lastColumn
 					}
 					next()
 				} else {
@@ -984,12 +1325,12 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 			pageDown = func() {
 				if t.rowsSelectable {
 					t.selectedRow += t.visibleRows
-					if t.selectedRow >= len(t.cells) {
-						t.selectedRow = len(t.cells) - 1
+					if t.selectedRow >= rowCount {
+						t.selectedRow = rowCount - 1
 					}
 					next()
 				} else {
-					t.rowOffset += t.visibleRows
+					t.scrollBy(t.visibleRows)
 				}
 			}
 
@@ -1002,7 +1343,7 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 					previous()
 				} else {
 					t.trackEnd = false
-					t.rowOffset -= t.visibleRows
+					t.scrollBy(-t.visibleRows)
 				}
 			}
 		)
@@ -1022,6 +1363,17 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 				left()
 			case 'l':
 				right()
+			case '/':
+				t.searching = true
+				t.searchText = ""
+			case 'n':
+				t.jumpToMatch(true, false, 0)
+			case 'N':
+				t.jumpToMatch(false, false, 0)
+			case 's':
+				t.cycleSort()
+			case 'S':
+				t.toggleSort(t.sortColumn)
 			}
 		case tcell.KeyHome:
 			home()
@@ -1040,16 +1392,167 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 		case tcell.KeyPgUp, tcell.KeyCtrlB:
 			pageUp()
 		case tcell.KeyEnter:
+			if t.columnsSelectable && t.selectedRow < t.fixedRows && t.toggleSort(t.selectedColumn) {
+				break // A header was selected.
+			}
 			if (t.rowsSelectable || t.columnsSelectable) && t.selected != nil {
 				t.selected(t.selectedRow, t.selectedColumn)
 			}
 		}
 
+		// Cells covered by other cells cannot be selected.
+		if t.rowsSelectable && t.columnsSelectable && position >= 0 && position <= lastColumn {
+			row, column := t.spanOrigin(t.selectedRow, order[position])
+			t.selectedRow, position = row, indexOf(order, column)
+		}
+
+		// Apply the new column position.
+		if position != previousPosition && position >= 0 && position <= lastColumn {
+			t.selectedColumn = order[position]
+		}
+
+		// Update the set of selected rows.
+		if t.multiSelect && t.rowsSelectable {
+			t.selectionInput(event, previouslySelectedRow)
+		}
+
 		// If the selection has changed, notify the handler.
-		if t.selectionChanged != nil &&
-			(t.rowsSelectable && previouslySelectedRow != t.selectedRow ||
-				t.columnsSelectable && previouslySelectedColumn != t.selectedColumn) {
-			t.selectionChanged(t.selectedRow, t.selectedColumn)
+		t.selectionMoved(previouslySelectedRow, previouslySelectedColumn)
+	})
+}
+
+// selectionMoved calls the "selectionChanged" handler if the selection is no
+// longer at the given position.
+func (t *Table) selectionMoved(previouslySelectedRow, previouslySelectedColumn int) {
+	if t.selectionChanged != nil &&
+		(t.rowsSelectable && previouslySelectedRow != t.selectedRow ||
+			t.columnsSelectable && previouslySelectedColumn != t.selectedColumn) {
+		t.selectionChanged(t.selectedRow, t.selectedColumn)
+	}
+}
+
+// cellAt returns the row and column of the cell found at the given screen
+// position the last time the table was drawn. For cells covered by another
+// cell, the covering cell is returned. If there is no such cell, -1 is
+// returned for both values.
+func (t *Table) cellAt(x, y int) (row, column int) {
+	rectX, rectY, _, _ := t.GetInnerRect()
+
+	// Which row?
+	rowY := y - rectY
+	if t.borders {
+		if rowY < 1 {
+			return -1, -1
+		}
+		rowY = (rowY - 1) / 2
+	}
+	if rowY < 0 || rowY >= len(t.drawnRows) {
+		return -1, -1
+	}
+
+	// Which column?
+	columnX := rectX
+	if !t.borders {
+		columnX--
+	}
+	for index, width := range t.drawnWidths {
+		// The separator between two columns belongs to a cell spanning both.
+		onCell := x > columnX && x <= columnX+width
+		onSpan := x == columnX+width+1 && index+1 < len(t.drawnWidths) && t.drawnSlot(rowY, index) == t.drawnSlot(rowY, index+1)
+		if onCell || onSpan {
+			slot := t.drawnSlot(rowY, index)
+			return t.drawnRows[slot.row], t.drawnColumns[slot.column]
+		}
+		columnX += width + 1
+	}
+
+	return -1, -1
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (t *Table) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		// The cell editor receives mouse events first. Clicking elsewhere
+		// commits the edit.
+		if t.editor != nil {
+			if t.editMouse(action, event) {
+				return true
+			}
+			if action == MouseLeftDown {
+				t.commitEdit()
+			}
+		}
+
+		// A column being resized follows the mouse, even outside the table.
+		if t.resizeColumn >= 0 && t.resizeMouse(action, event) {
+			return true
+		}
+
+		x, y := event.Position()
+		if !t.InRect(x, y) {
+			return false
+		}
+
+		// Process mouse event.
+		switch action {
+		case MouseLeftDown:
+			setFocus(t)
+			consumed = true
+			if t.columnsAdjustable {
+				t.resizeColumn, t.resizeX, t.resizeWidth = t.separatorAt(x, y)
+			}
+		case MouseLeftClick:
+			consumed = true
+			row, column := t.cellAt(x, y)
+			if row >= 0 && row < t.fixedRows && t.toggleSort(column) {
+				break // A header was clicked.
+			}
+			if !t.rowsSelectable && !t.columnsSelectable {
+				break
+			}
+			if row < 0 || t.GetCell(row, column).NotSelectable {
+				break
+			}
+			if modifiers := event.Modifiers(); t.multiSelect && t.rowsSelectable && modifiers&(tcell.ModCtrl|tcell.ModShift) != 0 {
+				// Change the set of selected rows.
+				previouslySelectedRow, previouslySelectedColumn := t.selectedRow, t.selectedColumn
+				t.selectedRow, t.selectedColumn = row, column
+				if modifiers&tcell.ModShift != 0 {
+					t.extendSelection(previouslySelectedRow)
+				} else {
+					t.SetRowSelected(row, !t.selectedRows[row])
+				}
+				t.selectionMoved(previouslySelectedRow, previouslySelectedColumn)
+				break
+			}
+			if row == t.selectedRow && column == t.selectedColumn {
+				// Clicking the current selection selects it.
+				if t.selected != nil {
+					t.selected(t.selectedRow, t.selectedColumn)
+				}
+				break
+			}
+			previouslySelectedRow, previouslySelectedColumn := t.selectedRow, t.selectedColumn
+			t.selectedRow, t.selectedColumn = row, column
+			t.selectionMoved(previouslySelectedRow, previouslySelectedColumn)
+		case MouseScrollUp, MouseScrollDown:
+			consumed = true
+			if t.rowsSelectable {
+				// Move the selection, just like the arrow keys.
+				key := tcell.KeyUp
+				if action == MouseScrollDown {
+					key = tcell.KeyDown
+				}
+				t.InputHandler()(tcell.NewEventKey(key, 0, tcell.ModNone), setFocus)
+				break
+			}
+			if action == MouseScrollUp {
+				t.trackEnd = false
+				t.scrollBy(-1)
+			} else {
+				t.scrollBy(1)
+			}
 		}
+		return
 	})
 }
@@ -0,0 +1,268 @@
+package tview
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+// sortTexts sorts the given texts as cells with the given function.
+func sortTexts(texts []string, less TableSortFunc) []string {
+	cells := make([]*TableCell, len(texts))
+	for index, text := range texts {
+		cells[index] = NewTableCell(text)
+	}
+	sort.SliceStable(cells, func(i, j int) bool {
+		return less(cells[i], cells[j])
+	})
+	sorted := make([]string, len(cells))
+	for index, cell := range cells {
+		sorted[index] = cell.Text
+	}
+	return sorted
+}
+
+func TestTableSortNumeric(t *testing.T) {
+	got := sortTexts([]string{"10", "abc", "9", "[red]-1.5", "", " 2 ", "Abb"}, TableSortNumeric)
+	want := []string{"[red]-1.5", " 2 ", "9", "10", "", "Abb", "abc"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted to %q, want %q", got, want)
+	}
+}
+
+func TestTableSortTime(t *testing.T) {
+	got := sortTexts([]string{"2021-03-01", "n/a", "2020-12-31", "2021-01-15"}, TableSortTime("2006-01-02"))
+	want := []string{"2020-12-31", "2021-01-15", "2021-03-01", "n/a"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted to %q, want %q", got, want)
+	}
+}
+
+func TestTableSortKeepsSelection(t *testing.T) {
+	table := NewTable().SetFixed(1, 0).SetSelectable(true, false).SetMultiSelect(true)
+	table.SetCellSimple(0, 0, "Name")
+	for row, name := range []string{"c", "a", "d", "b"} {
+		table.SetCellSimple(row+1, 0, name)
+	}
+	table.Select(4, 0)          // "b"
+	table.SetSelectedRows(1, 3) // "c" and "d"
+
+	table.SortBy(0, false)
+	if row, _ := table.GetSelection(); table.GetCell(row, 0).Text != "b" {
+		t.Errorf("selection moved to %q, want \"b\"", table.GetCell(row, 0).Text)
+	}
+	var selected []string
+	for _, row := range table.GetSelectedRows() {
+		selected = append(selected, table.GetCell(row, 0).Text)
+	}
+	if want := []string{"c", "d"}; !reflect.DeepEqual(selected, want) {
+		t.Errorf("selected rows are %q, want %q", selected, want)
+	}
+	if text := table.GetCell(0, 0).Text; text != "Name" {
+		t.Errorf("fixed row was sorted, it contains %q", text)
+	}
+
+	table.SortBy(0, true)
+	if row, _ := table.GetSelection(); row != 3 {
+		t.Errorf("selection is in row %d after sorting descending, want 3", row)
+	}
+}
+
+func TestTableSearchWrapsAround(t *testing.T) {
+	table := NewTable().SetSelectable(true, false)
+	for row := 0; row < 10; row++ {
+		table.SetCellSimple(row, 0, fmt.Sprintf("row %d", row))
+	}
+	table.SetCellSimple(2, 0, "match")
+	table.SetCellSimple(7, 0, "match")
+	table.SetSearchText("match")
+	next := func(key rune) int {
+		table.InputHandler()(tcell.NewEventKey(tcell.KeyRune, key, tcell.ModNone), func(Primitive) {})
+		row, _ := table.GetSelection()
+		return row
+	}
+
+	for _, step := range []struct {
+		key rune
+		row int
+	}{{'n', 2}, {'n', 7}, {'n', 2}, {'N', 7}, {'N', 2}} {
+		if row := next(step.key); row != step.row {
+			t.Fatalf("%q selected row %d, want %d", step.key, row, step.row)
+		}
+	}
+}
+
+func TestTableSearchLimit(t *testing.T) {
+	table := NewTable().SetSelectable(true, true)
+	for row := 0; row < 1000; row++ {
+		table.SetCellSimple(row, 0, "a")
+		table.SetCellSimple(row, 1, "b")
+	}
+	table.SetCellSimple(600, 1, "match")
+	table.SetSearchText("match")
+
+	// The match is the 1202nd cell, counting the current one.
+	if table.jumpToMatch(true, true, 1000) {
+		t.Error("match found beyond the limit")
+	}
+	if table.jumpToMatch(true, true, 1201) {
+		t.Error("match found beyond the limit")
+	}
+	if !table.jumpToMatch(true, true, 1202) {
+		t.Error("match within the limit not found")
+	}
+	if row, column := table.GetSelection(); row != 600 || column != 1 {
+		t.Errorf("selected %d/%d, want 600/1", row, column)
+	}
+
+	// Backwards, wrapping around.
+	table.Select(0, 0)
+	if !table.jumpToMatch(false, false, 0) {
+		t.Error("match not found backwards")
+	}
+	if row, _ := table.GetSelection(); row != 600 {
+		t.Errorf("selected row %d, want 600", row)
+	}
+}
+
+func TestTableFilterDeselectsHiddenRows(t *testing.T) {
+	table := NewTable().SetSelectable(true, false).SetMultiSelect(true)
+	for row := 0; row < 5; row++ {
+		table.SetCellSimple(row, 0, "x")
+	}
+	var changed []int
+	table.SetSelectedRowsChangedFunc(func(rows []int) {
+		changed = rows
+	})
+	table.SetSelectedRows(1, 2, 3)
+	table.SetFilterFunc(func(row int) bool {
+		return row != 2
+	})
+	if got, want := table.GetSelectedRows(), []int{1, 3}; !reflect.DeepEqual(got, want) || !reflect.DeepEqual(changed, want) {
+		t.Errorf("selected rows are %v (reported %v), want %v", got, changed, want)
+	}
+}
+
+func TestTableColumnLayoutRoundTrip(t *testing.T) {
+	table := NewTable()
+	for column := 0; column < 5; column++ {
+		table.SetCellSimple(0, column, fmt.Sprint(column))
+	}
+	table.SetColumnOrder(3, 1).
+		SetColumnHidden(4, true).
+		SetColumnHidden(0, true).
+		SetColumnWidth(1, 12)
+	layout := table.GetColumnLayout()
+	want := TableColumnLayout{
+		Order:  []int{3, 1},
+		Hidden: []int{0, 4},
+		Widths: map[int]int{1: 12},
+	}
+	if !reflect.DeepEqual(layout, want) {
+		t.Fatalf("layout is %+v, want %+v", layout, want)
+	}
+
+	// Store and restore it.
+	data, err := json.Marshal(layout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored TableColumnLayout
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatal(err)
+	}
+	other := NewTable()
+	for column := 0; column < 5; column++ {
+		other.SetCellSimple(0, column, fmt.Sprint(column))
+	}
+	other.SetColumnWidth(2, 5).SetColumnHidden(2, true) // Replaced by the layout.
+	other.SetColumnLayout(restored)
+	if got := other.GetColumnLayout(); !reflect.DeepEqual(got, want) {
+		t.Errorf("restored layout is %+v, want %+v", got, want)
+	}
+	if got, want := other.GetColumnOrder(), []int{3, 1, 0, 2, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("column order is %v, want %v", got, want)
+	}
+	if got, want := other.columnIndices(), []int{3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("drawn columns are %v, want %v", got, want)
+	}
+}
+
+// exportTable returns a table with a header row for the export tests.
+func exportTable() *Table {
+	table := NewTable().SetFixed(1, 0).SetSelectable(true, false)
+	table.SetCell(0, 0, NewTableCell("Name"))
+	table.SetCell(0, 1, NewTableCell("Size").SetAlign(AlignRight))
+	table.SetCell(0, 2, NewTableCell("Name"))
+	table.SetCellSimple(1, 0, "[red]a,b")
+	table.SetCellSimple(1, 1, "10")
+	table.SetCellSimple(1, 2, "x|y")
+	table.SetCellSimple(2, 0, `say "hi"`)
+	table.SetCellSimple(2, 1, "2")
+	return table
+}
+
+func TestTableExport(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		format TableExportFormat
+		want   string
+	}{
+		{"CSV", TableExportCSV, "Name,Size,Name\n\"a,b\",10,x|y\n\"say \"\"hi\"\"\",2,\n"},
+		{"TSV", TableExportTSV, "Name\tSize\tName\na,b\t10\tx|y\n\"say \"\"hi\"\"\"\t2\t\n"},
+		{"Markdown", TableExportMarkdown, "| Name | Size | Name |\n| --- | ---: | --- |\n| a,b | 10 | x\\|y |\n| say \"hi\" | 2 |  |\n"},
+		{"JSON", TableExportJSON, "[\n  {\"Name\": \"a,b\", \"Size\": \"10\", \"Name_2\": \"x|y\"},\n  {\"Name\": \"say \\\"hi\\\"\", \"Size\": \"2\", \"Name_2\": \"\"}\n]\n"},
+	} {
+		var buffer bytes.Buffer
+		if err := exportTable().Export(&buffer, test.format, 0); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if got := buffer.String(); got != test.want {
+			t.Errorf("%s export is\n%s\nwant\n%s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTableExportJSONIsValid(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := exportTable().Export(&buffer, TableExportJSON, 0); err != nil {
+		t.Fatal(err)
+	}
+	var records []map[string]string
+	if err := json.Unmarshal(buffer.Bytes(), &records); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buffer.String())
+	}
+	if len(records) != 2 || len(records[0]) != 3 {
+		t.Errorf("decoded %v, want 2 records with 3 keys each", records)
+	}
+}
+
+func TestTableExportFlags(t *testing.T) {
+	table := exportTable().SetColumnHidden(1, true).SetColumnOrder(2)
+	table.SetFilterFunc(func(row int) bool {
+		return row != 1
+	})
+	var buffer bytes.Buffer
+	if err := table.Export(&buffer, TableExportCSV, TableExportVisible); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buffer.String(), "Name,Name\n,\"say \"\"hi\"\"\"\n"; got != want {
+		t.Errorf("visible export is %q, want %q", got, want)
+	}
+
+	table = exportTable()
+	table.Select(1, 0)
+	buffer.Reset()
+	if err := table.Export(&buffer, TableExportCSV, TableExportSelection); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buffer.String(), "Name,Size,Name\n\"a,b\",10,x|y\n"; got != want {
+		t.Errorf("selection export is %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,352 @@
+package tview
+
+import (
+	"sort"
+
+	"github.com/gdamore/tcell"
+)
+
+// TableColumnLayout describes how a Table arranges its columns: their order,
+// which of them are hidden, and which of them have a fixed width. It is
+// returned by Table.GetColumnLayout() and can be stored (e.g. with
+// encoding/json) to restore the layout with Table.SetColumnLayout() later.
+//
+// All columns are referred to by their index in the table's content.
+type TableColumnLayout struct {
+	// The columns in the order in which they are drawn. Columns which are
+	// not listed follow in their natural order. If empty, all columns are
+	// drawn in their natural order.
+	Order []int `json:"order,omitempty"`
+
+	// The columns which are not drawn, in ascending order.
+	Hidden []int `json:"hidden,omitempty"`
+
+	// The widths of columns whose width does not depend on their cells.
+	Widths map[int]int `json:"widths,omitempty"`
+}
+
+// SetColumnsAdjustable sets whether or not the user may resize and move the
+// table's columns. The user then drags the separator (or border) to the right
+// of a column with the mouse to resize it. If columns are selectable (see
+// SetSelectable()), the following keys change the selected column:
+//
+//   - <, >: Make the column narrower / wider by one.
+//   - =: Let the column's cells determine its width again.
+//   - [, ]: Move the column to the left / right.
+//
+// See SetColumnLayoutChangedFunc() to be notified of these changes.
+func (t *Table) SetColumnsAdjustable(adjustable bool) *Table {
+	t.columnsAdjustable = adjustable
+	return t
+}
+
+// SetColumnLayoutChangedFunc sets a handler which is called when the user
+// resized or moved a column. It receives the new column layout, which may be
+// stored to restore it later.
+func (t *Table) SetColumnLayoutChangedFunc(handler func(layout TableColumnLayout)) *Table {
+	t.columnLayoutChanged = handler
+	return t
+}
+
+// SetColumnWidth sets the width of the given column in screen space,
+// regardless of the width of its cells. Cell texts which do not fit are cut
+// off. The column does not expand (see TableCell.SetExpansion()). Provide 0
+// to let the column's cells determine its width again.
+func (t *Table) SetColumnWidth(column, width int) *Table {
+	if width <= 0 {
+		delete(t.columnWidths, column)
+		return t
+	}
+	if t.columnWidths == nil {
+		t.columnWidths = make(map[int]int)
+	}
+	t.columnWidths[column] = width
+	return t
+}
+
+// GetColumnWidth returns the width set for the given column with
+// SetColumnWidth() or 0 if the column's cells determine its width.
+func (t *Table) GetColumnWidth(column int) int {
+	return t.columnWidths[column]
+}
+
+// SetColumnHidden sets whether or not the given column is hidden. Hidden
+// columns are not drawn and cannot be selected, but their cells remain in the
+// table's content.
+func (t *Table) SetColumnHidden(column int, hidden bool) *Table {
+	if !hidden {
+		delete(t.hiddenColumns, column)
+		return t
+	}
+	if t.hiddenColumns == nil {
+		t.hiddenColumns = make(map[int]bool)
+	}
+	t.hiddenColumns[column] = true
+	return t
+}
+
+// IsColumnHidden returns whether or not the given column is hidden.
+func (t *Table) IsColumnHidden(column int) bool {
+	return t.hiddenColumns[column]
+}
+
+// SetColumnOrder sets the order in which columns are drawn. The first columns
+// drawn are the given ones, all other columns follow in their natural order.
+// Fixed columns (see SetFixed()) are the leftmost columns drawn. Call this
+// function without arguments to draw all columns in their natural order.
+func (t *Table) SetColumnOrder(columns ...int) *Table {
+	if len(columns) == 0 {
+		t.columnOrder = nil
+		return t
+	}
+	t.columnOrder = append([]int(nil), columns...)
+	return t
+}
+
+// GetColumnOrder returns all columns of the table in the order in which they
+// are drawn, including hidden columns.
+func (t *Table) GetColumnOrder() []int {
+	return t.orderedColumns()
+}
+
+// MoveColumn moves the given column to the given position in the column
+// order, see GetColumnOrder(). Positions include hidden columns.
+func (t *Table) MoveColumn(column, position int) *Table {
+	order := t.orderedColumns()
+	from := indexOf(order, column)
+	if from < 0 {
+		return t
+	}
+	order = append(order[:from], order[from+1:]...)
+	if position < 0 {
+		position = 0
+	} else if position > len(order) {
+		position = len(order)
+	}
+	order = append(order, 0)
+	copy(order[position+1:], order[position:])
+	order[position] = column
+	t.columnOrder = order
+	return t
+}
+
+// GetColumnLayout returns the current order, visibility, and widths of the
+// table's columns.
+func (t *Table) GetColumnLayout() TableColumnLayout {
+	var layout TableColumnLayout
+	if len(t.columnOrder) > 0 {
+		layout.Order = append([]int(nil), t.columnOrder...)
+	}
+	for column := range t.hiddenColumns {
+		layout.Hidden = append(layout.Hidden, column)
+	}
+	sort.Ints(layout.Hidden)
+	if len(t.columnWidths) > 0 {
+		layout.Widths = make(map[int]int, len(t.columnWidths))
+		for column, width := range t.columnWidths {
+			layout.Widths[column] = width
+		}
+	}
+	return layout
+}
+
+// SetColumnLayout replaces the order, visibility, and widths of the table's
+// columns with the given layout, e.g. one previously returned by
+// GetColumnLayout().
+func (t *Table) SetColumnLayout(layout TableColumnLayout) *Table {
+	t.SetColumnOrder(layout.Order...)
+	t.hiddenColumns, t.columnWidths = nil, nil
+	for _, column := range layout.Hidden {
+		t.SetColumnHidden(column, true)
+	}
+	for column, width := range layout.Widths {
+		t.SetColumnWidth(column, width)
+	}
+	return t
+}
+
+// orderedColumns returns all columns of the table's content in the order in
+// which they are drawn, including hidden columns.
+func (t *Table) orderedColumns() []int {
+	columnCount := t.content.GetColumnCount()
+	order := make([]int, 0, columnCount)
+	listed := make(map[int]bool, len(t.columnOrder))
+	for _, column := range t.columnOrder {
+		if column >= 0 && column < columnCount && !listed[column] {
+			order = append(order, column)
+			listed[column] = true
+		}
+	}
+	for column := 0; column < columnCount; column++ {
+		if !listed[column] {
+			order = append(order, column)
+		}
+	}
+	return order
+}
+
+// columnIndices returns the columns which are drawn, in the order in which
+// they are drawn.
+func (t *Table) columnIndices() []int {
+	order := t.orderedColumns()
+	if len(t.hiddenColumns) == 0 {
+		return order
+	}
+	shown := order[:0]
+	for _, column := range order {
+		if !t.hiddenColumns[column] {
+			shown = append(shown, column)
+		}
+	}
+	return shown
+}
+
+// indexOf returns the index of the given value in the given list or -1 if it
+// is not in the list.
+func indexOf(list []int, value int) int {
+	for index, v := range list {
+		if v == value {
+			return index
+		}
+	}
+	return -1
+}
+
+// moveColumns moves the column layout's settings to new columns after columns
+// were inserted or removed. The function returns the new index of a column or
+// a negative value if the column no longer exists.
+func (t *Table) moveColumns(index func(column int) int) {
+	var order []int
+	for _, column := range t.columnOrder {
+		if column = index(column); column >= 0 {
+			order = append(order, column)
+		}
+	}
+	t.columnOrder = order
+	hidden, widths := t.hiddenColumns, t.columnWidths
+	t.hiddenColumns, t.columnWidths = nil, nil
+	for column := range hidden {
+		if column = index(column); column >= 0 {
+			t.SetColumnHidden(column, true)
+		}
+	}
+	for column, width := range widths {
+		if column = index(column); column >= 0 {
+			t.SetColumnWidth(column, width)
+		}
+	}
+}
+
+// notifyColumnLayout calls the "columnLayoutChanged" handler, if one is set.
+func (t *Table) notifyColumnLayout() {
+	if t.columnLayoutChanged != nil {
+		t.columnLayoutChanged(t.GetColumnLayout())
+	}
+}
+
+// columnInput processes the keys which resize or move the selected column,
+// see SetColumnsAdjustable(). It returns false if the key was not processed.
+func (t *Table) columnInput(r rune) bool {
+	column := t.selectedColumn
+	switch r {
+	case '<', '>':
+		width := t.columnWidths[column]
+		if width == 0 {
+			// Start at the width the column was last drawn with.
+			position := indexOf(t.drawnColumns, column)
+			if position < 0 {
+				return true
+			}
+			width = t.drawnWidths[position]
+		}
+		if r == '<' {
+			width--
+		} else {
+			width++
+		}
+		if width < 1 {
+			width = 1
+		}
+		t.SetColumnWidth(column, width)
+	case '=':
+		if t.columnWidths[column] == 0 {
+			return true
+		}
+		t.SetColumnWidth(column, 0)
+	case '[', ']':
+		// Hidden columns are skipped.
+		order := t.orderedColumns()
+		position := indexOf(order, column)
+		if position < 0 {
+			return true
+		}
+		step := 1
+		if r == '[' {
+			step = -1
+		}
+		target := position + step
+		for target >= 0 && target < len(order) && t.hiddenColumns[order[target]] {
+			target += step
+		}
+		if target < 0 || target >= len(order) {
+			return true
+		}
+		t.MoveColumn(column, target)
+	default:
+		return false
+	}
+	t.notifyColumnLayout()
+	return true
+}
+
+// separatorAt returns the column whose right separator (or border) was found
+// at the given screen position the last time the table was drawn, the screen
+// position of its left edge, and its width. If there is no such separator, -1
+// is returned for the column.
+func (t *Table) separatorAt(x, y int) (column, left, width int) {
+	rectX, rectY, _, _ := t.GetInnerRect()
+	rowY := y - rectY
+	if t.borders {
+		rowY /= 2
+	}
+	if rowY < 0 || rowY >= len(t.drawnRows) {
+		return -1, 0, 0
+	}
+	columnX := rectX
+	if !t.borders {
+		columnX--
+	}
+	for index, width := range t.drawnWidths {
+		if x == columnX+width+1 {
+			return t.drawnColumns[index], columnX + 1, width
+		}
+		columnX += width + 1
+	}
+	return -1, 0, 0
+}
+
+// resizeMouse processes a mouse event while a column is being resized. It
+// returns false if the event did not resize the column.
+func (t *Table) resizeMouse(action MouseAction, event *tcell.EventMouse) bool {
+	switch action {
+	case MouseMove:
+		if event.Buttons()&tcell.Button1 == 0 {
+			break
+		}
+		x, _ := event.Position()
+		width := x - t.resizeX
+		if width < 1 {
+			width = 1
+		}
+		t.SetColumnWidth(t.resizeColumn, width)
+		return true
+	case MouseLeftUp:
+		column, width := t.resizeColumn, t.resizeWidth
+		t.resizeColumn = -1
+		if t.columnWidths[column] != 0 && t.columnWidths[column] != width {
+			t.notifyColumnLayout()
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package tview
+
+// TableContent provides access to a Table's data. You may replace the Table
+// class's default implementation with your own using the Table.SetContent()
+// function. This allows you to turn Table into a view of your own data
+// structure, e.g. a database query or a log file with millions of rows. The
+// table only requests the cells it draws.
+//
+// The Table class calls GetCell(), GetRowCount(), and GetColumnCount(). The
+// remaining methods are called when the corresponding Table functions (e.g.
+// Table.SetCell() or Table.RemoveRow()) are called. If your data is read-only,
+// embed TableContentReadOnly which implements them as no-ops.
+type TableContent interface {
+	// Return the cell at the given position or nil if there is no cell. The
+	// row and column arguments start at 0 and end at what GetRowCount() and
+	// GetColumnCount() return, minus 1.
+	GetCell(row, column int) *TableCell
+
+	// Return the total number of rows in the table.
+	GetRowCount() int
+
+	// Return the total number of columns in the table.
+	GetColumnCount() int
+
+	// The following functions are provided for completeness reasons as the
+	// original Table implementation was not read-only. If you do not wish to
+	// forward modifying operations to your data, you may embed
+	// TableContentReadOnly into your struct to provide empty versions of them.
+
+	// Set the cell at the given position to the provided cell.
+	SetCell(row, column int, cell *TableCell)
+
+	// Remove the row at the given position by shifting all following rows up
+	// by one. Out of range positions may be ignored.
+	RemoveRow(row int)
+
+	// Remove the column at the given position by shifting all following
+	// columns left by one. Out of range positions may be ignored.
+	RemoveColumn(column int)
+
+	// Insert a new empty row at the given position by shifting all rows at
+	// that position and below down by one. Implementers may decide what to do
+	// with out of range positions.
+	InsertRow(row int)
+
+	// Insert a new empty column at the given position by shifting all columns
+	// at that position and to the right by one to the right. Implementers may
+	// decide what to do with out of range positions.
+	InsertColumn(column int)
+
+	// Remove all table data.
+	Clear()
+}
+
+// TableContentReadOnly is an empty struct which implements the write
+// operations of the TableContent interface. None of the implemented functions
+// do anything. You can embed this struct into your own structs to free
+// yourself from having to implement the empty write functions of
+// TableContent.
+type TableContentReadOnly struct{}
+
+// SetCell does not do anything.
+func (t TableContentReadOnly) SetCell(row, column int, cell *TableCell) {
+	// nop.
+}
+
+// RemoveRow does not do anything.
+func (t TableContentReadOnly) RemoveRow(row int) {
+	// nop.
+}
+
+// RemoveColumn does not do anything.
+func (t TableContentReadOnly) RemoveColumn(column int) {
+	// nop.
+}
+
+// InsertRow does not do anything.
+func (t TableContentReadOnly) InsertRow(row int) {
+	// nop.
+}
+
+// InsertColumn does not do anything.
+func (t TableContentReadOnly) InsertColumn(column int) {
+	// nop.
+}
+
+// Clear does not do anything.
+func (t TableContentReadOnly) Clear() {
+	// nop.
+}
+
+// tableDefaultContent implements the default TableContent interface for the
+// Table class.
+type tableDefaultContent struct {
+	// The cells of the table. Rows first, then columns.
+	cells [][]*TableCell
+
+	// The rightmost column in the data set.
+	lastColumn int
+}
+
+// Clear clears all data.
+func (t *tableDefaultContent) Clear() {
+	t.cells = nil
+	t.lastColumn = -1
+}
+
+// SetCell sets a cell's content.
+func (t *tableDefaultContent) SetCell(row, column int, cell *TableCell) {
+	if row >= len(t.cells) {
+		t.cells = append(t.cells, make([][]*TableCell, row-len(t.cells)+1)...)
+	}
+	rowLen := len(t.cells[row])
+	if column >= rowLen {
+		t.cells[row] = append(t.cells[row], make([]*TableCell, column-rowLen+1)...)
+		for c := rowLen; c < column; c++ {
+			t.cells[row][c] = &TableCell{}
+		}
+	}
+	t.cells[row][column] = cell
+	if column > t.lastColumn {
+		t.lastColumn = column
+	}
+}
+
+// RemoveRow removes a row from the data.
+func (t *tableDefaultContent) RemoveRow(row int) {
+	if row < 0 || row >= len(t.cells) {
+		return
+	}
+	t.cells = append(t.cells[:row], t.cells[row+1:]...)
+}
+
+// RemoveColumn removes a column from the data.
+func (t *tableDefaultContent) RemoveColumn(column int) {
+	for row := range t.cells {
+		if column < 0 || column >= len(t.cells[row]) {
+			continue
+		}
+		t.cells[row] = append(t.cells[row][:column], t.cells[row][column+1:]...)
+	}
+	if column >= 0 && column <= t.lastColumn {
+		t.lastColumn--
+	}
+}
+
+// InsertRow inserts a new row at the given position.
+func (t *tableDefaultContent) InsertRow(row int) {
+	if row >= len(t.cells) {
+		return
+	}
+	t.cells = append(t.cells, nil)       // Extend by one.
+	copy(t.cells[row+1:], t.cells[row:]) // Shift down.
+	t.cells[row] = nil                   // New row is uninitialized.
+}
+
+// InsertColumn inserts a new column at the given position.
+func (t *tableDefaultContent) InsertColumn(column int) {
+	for row := range t.cells {
+		if column >= len(t.cells[row]) {
+			continue
+		}
+		t.cells[row] = append(t.cells[row], nil)             // Extend by one.
+		copy(t.cells[row][column+1:], t.cells[row][column:]) // Shift to the right.
+		t.cells[row][column] = &TableCell{}                  // New element is an uninitialized table cell.
+	}
+	if column >= 0 && column <= t.lastColumn {
+		t.lastColumn++
+	}
+}
+
+// GetCell returns the cell at the given position.
+func (t *tableDefaultContent) GetCell(row, column int) *TableCell {
+	if row < 0 || column < 0 || row >= len(t.cells) || column >= len(t.cells[row]) {
+		return nil
+	}
+	return t.cells[row][column]
+}
+
+// GetRowCount returns the number of rows in the data set.
+func (t *tableDefaultContent) GetRowCount() int {
+	return len(t.cells)
+}
+
+// GetColumnCount returns the number of columns in the data set.
+func (t *tableDefaultContent) GetColumnCount() int {
+	if len(t.cells) == 0 {
+		return 0
+	}
+	return t.lastColumn + 1
+}
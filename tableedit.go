@@ -0,0 +1,286 @@
+package tview
+
+import (
+	"strconv"
+
+	"github.com/gdamore/tcell"
+)
+
+// SetEditable sets whether or not the cells of the table can be edited in
+// place. If both rows and columns are selectable (see SetSelectable()), the
+// edit key (see SetEditKey()) then opens an editor on top of the selected
+// cell. Enter commits the new text, Escape cancels editing. Fixed rows and
+// cells which are not selectable cannot be edited.
+func (t *Table) SetEditable(editable bool) *Table {
+	t.editable = editable
+	return t
+}
+
+// SetEditKey sets the key which starts editing the selected cell, see
+// SetEditable(). The default is Enter, in which case the "selected" handler
+// (see SetSelectedFunc()) is not called for editable tables.
+func (t *Table) SetEditKey(key tcell.Key) *Table {
+	t.editKey = key
+	return t
+}
+
+// SetColumnEditor sets the form item used to edit the cells of the given
+// column. It replaces the default, an InputField. Supported form items are:
+//
+//   - InputField: Edits the cell's text.
+//   - DropDown: Selects the option whose text (or name) is the cell's text.
+//     Selecting an option commits its text.
+//   - Checkbox: Is checked if the cell's text is "true" (see
+//     strconv.ParseBool()). It commits "true" or "false".
+//
+// Other form items must implement FormItemValue and receive the cell's text
+// as their value. The editor is resized to cover the cell. Its finished
+// handler (see FormItem.SetFinishedFunc()) is replaced by the table. Provide
+// nil to use the default editor again.
+func (t *Table) SetColumnEditor(column int, editor FormItem) *Table {
+	if t.editors == nil {
+		t.editors = make(map[int]FormItem)
+	}
+	if editor == nil {
+		delete(t.editors, column)
+	} else {
+		t.editors[column] = editor
+	}
+	return t
+}
+
+// SetCellEditedFunc sets a handler which is called when the user committed a
+// changed cell text. It receives the cell's position as well as its old and
+// new text. If it returns false, the change is discarded. Otherwise, the new
+// text is stored in the cell.
+func (t *Table) SetCellEditedFunc(handler func(row, column int, oldText, newText string) bool) *Table {
+	t.cellEdited = handler
+	return t
+}
+
+// EditCell opens the editor for the cell at the given position, as if the
+// user had pressed the edit key on it. This works even if the table is not
+// editable. Nothing happens if the cell does not exist, is not selectable, or
+// has not been drawn yet.
+func (t *Table) EditCell(row, column int) *Table {
+	cell := t.content.GetCell(row, column)
+	if cell == nil || cell.NotSelectable || row < t.fixedRows {
+		return t
+	}
+	if _, _, _, drawn := t.drawnCellRect(row, column); !drawn {
+		return t
+	}
+	t.cancelEdit()
+
+	editor := t.editors[column]
+	if editor == nil {
+		editor = NewInputField().
+			SetFieldBackgroundColor(Styles.ContrastBackgroundColor).
+			SetFieldTextColor(Styles.PrimaryTextColor)
+	}
+	setEditorText(editor, cell.Text)
+	editor.SetFinishedFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEscape:
+			t.cancelEdit()
+		case tcell.KeyEnter, tcell.KeyTab, tcell.KeyBacktab:
+			t.commitEdit()
+		}
+	})
+
+	t.editor, t.editRow, t.editColumn, t.editText = editor, row, column, cell.Text
+	t.editFocus = nil
+	t.setEditFocus(editor)
+
+	// Drop-downs are edited in their list of options.
+	if dropDown, ok := editor.(*DropDown); ok {
+		dropDown.openList(t.setEditFocus)
+	}
+	return t
+}
+
+// IsEditing returns whether or not a cell is currently being edited.
+func (t *Table) IsEditing() bool {
+	return t.editor != nil
+}
+
+// setEditFocus hands the focus to a primitive of the cell editor. It is used
+// instead of the application's focus because the table keeps the focus while
+// editing.
+func (t *Table) setEditFocus(p Primitive) {
+	if t.editFocus != nil && t.editFocus != p {
+		t.editFocus.Blur()
+	}
+	t.editFocus = p
+	p.Focus(func(p Primitive) {
+		t.editFocus = p
+	})
+}
+
+// editInput forwards a key event to the cell editor.
+func (t *Table) editInput(event *tcell.EventKey) {
+	editor := t.editor
+	if handler := t.editFocus.InputHandler(); handler != nil {
+		handler(event, t.setEditFocus)
+	}
+
+	// A drop-down's list was closed, by selecting an option or by pressing
+	// Escape.
+	if dropDown, ok := editor.(*DropDown); ok && t.editor == editor && !dropDown.open {
+		if event.Key() == tcell.KeyEscape {
+			t.cancelEdit()
+		} else {
+			t.commitEdit()
+		}
+	}
+}
+
+// editMouse forwards a mouse event to the cell editor. It returns false if
+// the editor did not consume the event.
+func (t *Table) editMouse(action MouseAction, event *tcell.EventMouse) bool {
+	editor := t.editor
+	if handler := t.editFocus.MouseHandler(); handler != nil && handler(action, event, t.setEditFocus) {
+		if dropDown, ok := editor.(*DropDown); ok && t.editor == editor && !dropDown.open {
+			t.commitEdit()
+		}
+		return true
+	}
+	if handler := editor.MouseHandler(); editor != t.editFocus && handler != nil {
+		return handler(action, event, t.setEditFocus)
+	}
+	return false
+}
+
+// commitEdit closes the cell editor and stores the new text in the cell,
+// unless the "cellEdited" handler vetoes it.
+func (t *Table) commitEdit() {
+	if t.editor == nil {
+		return
+	}
+	row, column, oldText, newText := t.editRow, t.editColumn, t.editText, getEditorText(t.editor)
+	t.cancelEdit()
+	if newText == oldText {
+		return
+	}
+	if t.cellEdited != nil && !t.cellEdited(row, column, oldText, newText) {
+		return
+	}
+	if cell := t.content.GetCell(row, column); cell != nil {
+		cell.Text = newText
+		t.content.SetCell(row, column, cell)
+	}
+}
+
+// cancelEdit closes the cell editor without changing the cell.
+func (t *Table) cancelEdit() {
+	if t.editor == nil {
+		return
+	}
+	if t.editFocus != nil {
+		t.editFocus.Blur()
+	}
+	if dropDown, ok := t.editor.(*DropDown); ok {
+		dropDown.open = false
+	}
+	t.editor.Blur()
+	t.editor, t.editFocus = nil, nil
+}
+
+// drawEditor draws the cell editor on top of the edited cell. If the cell is
+// no longer drawn, e.g. because the table was scrolled, editing is canceled.
+func (t *Table) drawEditor(screen tcell.Screen) {
+	x, y, width, drawn := t.drawnCellRect(t.editRow, t.editColumn)
+	if !drawn {
+		t.cancelEdit()
+		return
+	}
+	// Form items leave one space after their (empty) label. It covers the
+	// separator to the left of the cell.
+	t.editor.SetRect(x-1, y, width+1, 1)
+	t.editor.Draw(screen)
+}
+
+// drawnCellRect returns the screen position and the (visible) width of the
+// cell at the given position the last time the table was drawn. The position
+// is calculated from the drawn rows and columns rather than taken from the
+// cell because a TableContent may return a new cell each time. The last
+// return value is false if the cell was not drawn or covered by another cell.
+func (t *Table) drawnCellRect(row, column int) (x, y, width int, drawn bool) {
+	rowIndex, columnIndex := indexOf(t.drawnRows, row), indexOf(t.drawnColumns, column)
+	span, ok := t.drawnSpan(row, column)
+	if !ok || span.row != rowIndex || span.column != columnIndex {
+		return
+	}
+	rectX, rectY, rectWidth, rectHeight := t.GetInnerRect()
+	if t.searching {
+		rectHeight-- // The search prompt.
+	}
+
+	x, y = rectX+1, rectY+rowIndex
+	if !t.borders {
+		x--
+	} else {
+		y += rowIndex + 1
+	}
+	for index := 0; index < columnIndex; index++ {
+		x += t.drawnWidths[index] + 1
+	}
+	width = span.columns - 1 // The separators between spanned columns.
+	for offset := 0; offset < span.columns; offset++ {
+		width += t.drawnWidths[columnIndex+offset]
+	}
+	if x >= rectX+rectWidth || y >= rectY+rectHeight {
+		return 0, 0, 0, false
+	}
+	if x+width > rectX+rectWidth {
+		width = rectX + rectWidth - x
+	}
+	return x, y, width, true
+}
+
+// setEditorText sets the value of a cell editor to the given cell text, see
+// Table.SetColumnEditor().
+func setEditorText(editor FormItem, text string) {
+	switch editor := editor.(type) {
+	case *InputField:
+		editor.SetText(text)
+	case *Checkbox:
+		checked, _ := strconv.ParseBool(text)
+		editor.SetChecked(checked)
+	case *DropDown:
+		current := -1
+		for index, option := range editor.options {
+			if stripTags(option.Text) == stripTags(text) || option.Name == text {
+				current = index
+				break
+			}
+		}
+		editor.SetCurrentOption(current)
+	case FormItemValue:
+		editor.SetValue(text)
+	}
+}
+
+// getEditorText returns the cell text for the current value of a cell editor,
+// see Table.SetColumnEditor().
+func getEditorText(editor FormItem) string {
+	switch editor := editor.(type) {
+	case *InputField:
+		return editor.GetText()
+	case *Checkbox:
+		return strconv.FormatBool(editor.IsChecked())
+	case *DropDown:
+		_, text := editor.GetCurrentOption()
+		return text
+	case FormItemValue:
+		return formValueText(editor.GetValue())
+	}
+	return ""
+}
+
+// Blur is called when this primitive loses focus. An open cell editor commits
+// its text.
+func (t *Table) Blur() {
+	t.commitEdit()
+	t.Box.Blur()
+}
@@ -0,0 +1,214 @@
+package tview
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TableExportFormat is a format in which Table.Export() writes the table's
+// cells.
+type TableExportFormat int
+
+// Formats in which a table can be exported.
+const (
+	// Comma-separated values (RFC 4180), one line per row.
+	TableExportCSV TableExportFormat = iota
+
+	// Tab-separated values, quoted like comma-separated values.
+	TableExportTSV
+
+	// A Markdown table whose first row is the header row.
+	TableExportMarkdown
+
+	// A JSON array with one object per row after the header row. The objects'
+	// keys are the texts of the header row's cells. Empty keys are replaced by
+	// the column index, duplicate keys are followed by "_" and the column
+	// index.
+	TableExportJSON
+)
+
+// Flags which restrict the cells written by Table.Export(). They can be
+// combined.
+const (
+	// Export only the rows shown by the filter (see Table.SetFilterFunc())
+	// and the columns which are not hidden, in the order in which they are
+	// drawn (see Table.SetColumnOrder()).
+	TableExportVisible = 1 << iota
+
+	// Export only the current selection: The selected row (or, in
+	// multi-select mode, all selected rows, if any), the selected column, or
+	// the selected cell, along with the header rows.
+	TableExportSelection
+)
+
+// Export writes the table's cells to the given writer in the given format.
+// Color tags are removed from the cells' texts. The "flags" argument restricts
+// the exported cells, see TableExportVisible and TableExportSelection. Provide
+// 0 to export all cells in their natural order.
+//
+// The header row is the last fixed row (see SetFixed()) or, if there are no
+// fixed rows, the first row. Header rows are always exported. The Markdown and
+// JSON formats use the header row to name the columns and omit any rows above
+// it. If the table has no header row, nothing is written.
+func (t *Table) Export(w io.Writer, format TableExportFormat, flags int) error {
+	rows, columns, header := t.exportCells(flags)
+	if header < 0 {
+		return nil
+	}
+	text := func(row, column int) string {
+		cell := t.content.GetCell(row, column)
+		if cell == nil {
+			return ""
+		}
+		return stripTags(cell.Text)
+	}
+
+	switch format {
+	case TableExportCSV, TableExportTSV:
+		writer := csv.NewWriter(w)
+		if format == TableExportTSV {
+			writer.Comma = '\t'
+		}
+		for _, row := range rows {
+			record := make([]string, len(columns))
+			for index, column := range columns {
+				record[index] = text(row, column)
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case TableExportMarkdown:
+		writer := bufio.NewWriter(w)
+		escape := strings.NewReplacer("|", `\|`, "\n", " ")
+		writeRow := func(row int) {
+			writer.WriteString("|")
+			for _, column := range columns {
+				writer.WriteString(" " + escape.Replace(text(row, column)) + " |")
+			}
+			writer.WriteString("\n")
+		}
+		writeRow(header)
+		writer.WriteString("|")
+		for _, column := range columns {
+			separator := " --- |"
+			if cell := t.content.GetCell(header, column); cell != nil {
+				switch cell.Align {
+				case AlignCenter:
+					separator = " :---: |"
+				case AlignRight:
+					separator = " ---: |"
+				}
+			}
+			writer.WriteString(separator)
+		}
+		writer.WriteString("\n")
+		for _, row := range rows {
+			if row > header {
+				writeRow(row)
+			}
+		}
+		return writer.Flush()
+
+	case TableExportJSON:
+		// Columns without a header are named by their index. Duplicate names
+		// receive the column index as a suffix.
+		keys := make([][]byte, len(columns))
+		used := make(map[string]bool)
+		for index, column := range columns {
+			key := text(header, column)
+			if key == "" {
+				key = strconv.Itoa(column)
+			}
+			for used[key] {
+				key += "_" + strconv.Itoa(column)
+			}
+			used[key] = true
+			keys[index], _ = json.Marshal(key)
+		}
+		writer := bufio.NewWriter(w)
+		writer.WriteString("[")
+		var records int
+		for _, row := range rows {
+			if row <= header {
+				continue
+			}
+			if records > 0 {
+				writer.WriteString(",")
+			}
+			records++
+			writer.WriteString("\n  {")
+			for index, column := range columns {
+				if index > 0 {
+					writer.WriteString(", ")
+				}
+				value, _ := json.Marshal(text(row, column))
+				writer.Write(keys[index])
+				writer.WriteString(": ")
+				writer.Write(value)
+			}
+			writer.WriteString("}")
+		}
+		if records > 0 {
+			writer.WriteString("\n")
+		}
+		writer.WriteString("]\n")
+		return writer.Flush()
+	}
+	return nil
+}
+
+// exportCells returns the rows and columns exported by Export() for the given
+// flags, as well as the header row (-1 if there is none).
+func (t *Table) exportCells(flags int) (rows, columns []int, header int) {
+	rowCount := t.content.GetRowCount()
+	header = t.fixedRows - 1
+	if header < 0 {
+		header = 0
+	}
+	if header >= rowCount {
+		return nil, nil, -1
+	}
+
+	// Which columns?
+	if flags&TableExportVisible != 0 {
+		columns = t.columnIndices()
+	} else {
+		for column := 0; column < t.content.GetColumnCount(); column++ {
+			columns = append(columns, column)
+		}
+	}
+	selection := flags&TableExportSelection != 0
+	if selection && t.columnsSelectable {
+		if indexOf(columns, t.selectedColumn) < 0 {
+			columns = nil
+		} else {
+			columns = []int{t.selectedColumn}
+		}
+	}
+
+	// Which rows?
+	selectedRows := map[int]bool{t.selectedRow: true}
+	if t.multiSelect && len(t.selectedRows) > 0 {
+		selectedRows = t.selectedRows
+	}
+	for row := 0; row < rowCount; row++ {
+		if row > header {
+			if flags&TableExportVisible != 0 && !t.rowVisible(row) {
+				continue
+			}
+			if selection && t.rowsSelectable && !selectedRows[row] {
+				continue
+			}
+		}
+		rows = append(rows, row)
+	}
+	return
+}
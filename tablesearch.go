@@ -0,0 +1,259 @@
+package tview
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell"
+)
+
+// SetFilterFunc sets a function which decides which rows are shown. Rows for
+// which it returns false are neither drawn nor can they be navigated to, but
+// they remain in the table's content. Fixed rows are always shown. Provide
+// nil to show all rows again.
+//
+// Rows selected in multi-select mode (see SetMultiSelect()) which the new
+// function hides are deselected. If the function's result changes later,
+// call SetFilterFunc() again.
+//
+// The function is called for rows as they are drawn or navigated, so it
+// should be fast.
+func (t *Table) SetFilterFunc(handler func(row int) bool) *Table {
+	t.filter = handler
+	t.moveSelectedRows(func(row int) int {
+		if !t.rowVisible(row) {
+			return -1
+		}
+		return row
+	})
+	return t
+}
+
+// SetSearchText sets the text which cells are searched for, as if the user
+// had entered it at the search prompt. Matching cells are highlighted (see
+// SetSearchHighlightColor()). Provide an empty string to remove the
+// highlight.
+func (t *Table) SetSearchText(text string) *Table {
+	t.searchText = text
+	return t
+}
+
+// GetSearchText returns the text which cells are searched for.
+func (t *Table) GetSearchText() string {
+	return t.searchText
+}
+
+// SetSearchHighlightColor sets the background color of cells which contain
+// the search text.
+func (t *Table) SetSearchHighlightColor(color tcell.Color) *Table {
+	t.searchHighlightColor = color
+	return t
+}
+
+// tableTypingSearchLimit is the maximum number of cells searched after each
+// key typed at the search prompt. This keeps typing fast in huge tables.
+// Pressing Enter searches the entire table.
+const tableTypingSearchLimit = 1000
+
+// rowVisible returns whether the given row is shown, see SetFilterFunc().
+func (t *Table) rowVisible(row int) bool {
+	return t.filter == nil || row < t.fixedRows || t.filter(row)
+}
+
+// visibleRowCount returns the number of shown rows from row "from" to row
+// "to" (inclusive). Counting stops when "limit" is reached.
+func (t *Table) visibleRowCount(from, to, limit int) int {
+	if t.filter == nil {
+		if to < from {
+			return 0
+		}
+		return to - from + 1
+	}
+	var count int
+	for row := from; row <= to && count < limit; row++ {
+		if t.rowVisible(row) {
+			count++
+		}
+	}
+	return count
+}
+
+// scrollOffset returns the row offset at which the given row is the last of
+// "count" shown rows after the fixed rows.
+func (t *Table) scrollOffset(row, count int) int {
+	if t.filter == nil {
+		return row - count + 1 - t.fixedRows
+	}
+	for ; row > t.fixedRows; row-- {
+		if t.rowVisible(row) {
+			count--
+			if count <= 0 {
+				break
+			}
+		}
+	}
+	return row - t.fixedRows
+}
+
+// cellMatches returns whether the given cell contains the search text,
+// ignoring case and color tags.
+func (t *Table) cellMatches(cell *TableCell) bool {
+	if t.searchText == "" || cell == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(stripTags(cell.Text)), strings.ToLower(t.searchText))
+}
+
+// searchInput processes a key event while the search prompt is shown. As the
+// search text is typed, the selection moves to the next match among the
+// following cells (see tableTypingSearchLimit). Enter closes the prompt and
+// moves to the next match in the entire table. Escape also removes the search
+// text.
+func (t *Table) searchInput(event *tcell.EventKey) {
+	switch event.Key() {
+	case tcell.KeyRune:
+		t.searchText += string(event.Rune())
+		t.jumpToMatch(true, true, tableTypingSearchLimit)
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if t.searchText == "" {
+			t.searching = false
+			break
+		}
+		runes := []rune(t.searchText)
+		t.searchText = string(runes[:len(runes)-1])
+		t.jumpToMatch(true, true, tableTypingSearchLimit)
+	case tcell.KeyEnter:
+		t.searching = false
+		t.jumpToMatch(true, true, 0)
+	case tcell.KeyEscape:
+		t.searching = false
+		t.searchText = ""
+	}
+}
+
+// jumpToMatch selects the next (or, if "forward" is false, the previous) cell
+// which contains the search text, starting at the current selection and
+// wrapping around. If "includeCurrent" is true, the current selection is
+// checked first. If entire rows are selected, rows with a matching cell are
+// searched. Hidden columns are not searched. If nothing is selectable, the
+// table is scrolled to the next matching row. At most "limit" cells are
+// checked, 0 for no limit. It returns false if there is no match.
+func (t *Table) jumpToMatch(forward, includeCurrent bool, limit int) bool {
+	rowCount, columnCount := t.content.GetRowCount(), t.content.GetColumnCount()
+	if t.searchText == "" || rowCount <= t.fixedRows || columnCount == 0 {
+		return false
+	}
+	selectable := t.rowsSelectable || t.columnsSelectable
+
+	// Positions are counted in cells or, if entire rows are searched, in rows.
+	row, column := t.selectedRow, t.selectedColumn
+	if !selectable {
+		row = t.fixedRows + t.rowOffset
+	}
+	if !t.columnsSelectable {
+		column, columnCount = 0, 1
+	}
+	if row < 0 || row >= rowCount || column < 0 || column >= columnCount {
+		row, column = t.fixedRows, 0
+	}
+
+	matches := func(row, column int) bool {
+		if !t.rowVisible(row) {
+			return false
+		}
+		if t.columnsSelectable {
+			cell := t.content.GetCell(row, column)
+			return !t.hiddenColumns[column] && t.cellMatches(cell) && (!selectable || !cell.NotSelectable)
+		}
+		for column := 0; column < t.content.GetColumnCount(); column++ {
+			if t.hiddenColumns[column] {
+				continue
+			}
+			if cell := t.content.GetCell(row, column); t.cellMatches(cell) && (!selectable || !cell.NotSelectable) {
+				return true
+			}
+		}
+		return false
+	}
+
+	step, total := 1, rowCount*columnCount
+	if !forward {
+		step = -1
+	}
+	if limit > 0 && !t.columnsSelectable {
+		// Each position is an entire row.
+		limit = (limit + t.content.GetColumnCount() - 1) / t.content.GetColumnCount()
+	}
+	positions := total
+	if limit > 0 && limit < positions {
+		positions = limit
+	}
+	start := row*columnCount + column
+	for offset := 1; offset <= positions; offset++ {
+		position := start + step*offset
+		if includeCurrent {
+			position -= step
+		}
+		position = (position%total + total) % total
+		row, column := position/columnCount, position%columnCount
+		if row < t.fixedRows || !matches(row, column) {
+			continue
+		}
+
+		// Move the selection or scroll to the match.
+		if t.rowsSelectable {
+			t.selectedRow = row
+		}
+		if t.columnsSelectable {
+			t.selectedColumn = column
+		}
+		if !t.rowsSelectable {
+			t.trackEnd = false
+			t.rowOffset = row - t.fixedRows
+		}
+		return true
+	}
+	return false
+}
+
+// drawSearchPrompt draws the search prompt in the given row.
+func (t *Table) drawSearchPrompt(screen tcell.Screen, x, y, width int) {
+	text := "/" + Escape(t.searchText)
+	for index := 0; index < width; index++ {
+		screen.SetContent(x+index, y, ' ', nil, tcell.StyleDefault.Background(t.backgroundColor))
+	}
+	Print(screen, text, x, y, width, AlignLeft, Styles.PrimaryTextColor)
+	if cursor := x + StringWidth(text); cursor < x+width {
+		screen.ShowCursor(cursor, y)
+	}
+}
+
+// scrollBy scrolls the table down by the given number of shown rows, or up if
+// the number is negative. Rows hidden by the filter are skipped.
+func (t *Table) scrollBy(rows int) {
+	if t.filter == nil {
+		t.rowOffset += rows
+		return
+	}
+
+	// Start at the first row which is actually drawn.
+	rowCount := t.content.GetRowCount()
+	if t.rowOffset < 0 {
+		t.rowOffset = 0
+	}
+	for t.fixedRows+t.rowOffset < rowCount && !t.rowVisible(t.fixedRows+t.rowOffset) {
+		t.rowOffset++
+	}
+
+	for ; rows > 0; rows-- {
+		t.rowOffset++
+		for t.fixedRows+t.rowOffset < rowCount && !t.rowVisible(t.fixedRows+t.rowOffset) {
+			t.rowOffset++
+		}
+	}
+	for ; rows < 0 && t.rowOffset > 0; rows++ {
+		t.rowOffset--
+		for t.rowOffset > 0 && !t.rowVisible(t.fixedRows+t.rowOffset) {
+			t.rowOffset--
+		}
+	}
+}
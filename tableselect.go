@@ -0,0 +1,214 @@
+package tview
+
+import (
+	"sort"
+
+	"github.com/gdamore/tcell"
+)
+
+// SetMultiSelect sets whether or not multiple rows can be selected at once.
+// This requires rows to be selectable (see SetSelectable()). In addition to
+// the current selection, the user may then select a set of rows:
+//
+//   - Shift-Up, Shift-Down, Shift-Home, Shift-End, Shift-PgUp, Shift-PgDn:
+//     Move the current selection and select all rows it passes.
+//   - Space: Select or deselect the current row.
+//   - Ctrl-A: Select all rows.
+//   - Ctrl-D: Deselect all rows.
+//
+// Clicking on a row with Ctrl held down selects or deselects it, with Shift
+// held down selects all rows up to it. Fixed rows and rows hidden by the
+// filter (see SetFilterFunc()) cannot be selected. Leaving multi-select mode
+// deselects all rows.
+func (t *Table) SetMultiSelect(multiSelect bool) *Table {
+	t.multiSelect = multiSelect
+	if !multiSelect {
+		t.SelectNoRows()
+	}
+	return t
+}
+
+// IsMultiSelect returns whether or not multiple rows can be selected at once.
+func (t *Table) IsMultiSelect() bool {
+	return t.multiSelect
+}
+
+// SetSelectedRowsChangedFunc sets a handler which is called when the set of
+// selected rows changed in multi-select mode. The handler receives the
+// selected rows in ascending order.
+func (t *Table) SetSelectedRowsChangedFunc(handler func(rows []int)) *Table {
+	t.selectedRowsChanged = handler
+	return t
+}
+
+// SetSelectedRowsColor sets the background color of the rows selected in
+// multi-select mode, except for the current selection which is drawn as
+// usual.
+func (t *Table) SetSelectedRowsColor(color tcell.Color) *Table {
+	t.selectedRowsColor = color
+	return t
+}
+
+// GetSelectedRows returns the rows selected in multi-select mode in ascending
+// order. The current selection (see GetSelection()) is not included unless
+// it was selected, too.
+func (t *Table) GetSelectedRows() []int {
+	rows := make([]int, 0, len(t.selectedRows))
+	for row := range t.selectedRows {
+		rows = append(rows, row)
+	}
+	sort.Ints(rows)
+	return rows
+}
+
+// SetSelectedRows selects the given rows and deselects all others.
+func (t *Table) SetSelectedRows(rows ...int) *Table {
+	selected := make(map[int]bool)
+	for _, row := range rows {
+		if t.rowSelectable(row) {
+			selected[row] = true
+		}
+	}
+	t.selectionAnchor = -1
+	t.updateSelectedRows(selected)
+	return t
+}
+
+// IsRowSelected returns whether or not the given row is selected in
+// multi-select mode.
+func (t *Table) IsRowSelected(row int) bool {
+	return t.selectedRows[row]
+}
+
+// SetRowSelected selects or deselects the given row.
+func (t *Table) SetRowSelected(row int, selected bool) *Table {
+	rows := t.copySelectedRows()
+	if selected && t.rowSelectable(row) {
+		rows[row] = true
+	} else {
+		delete(rows, row)
+	}
+	t.selectionAnchor = -1
+	t.updateSelectedRows(rows)
+	return t
+}
+
+// SelectAllRows selects all rows, except for fixed rows and rows hidden by
+// the filter.
+func (t *Table) SelectAllRows() *Table {
+	rows := make(map[int]bool)
+	for row := t.fixedRows; row < t.content.GetRowCount(); row++ {
+		if t.rowVisible(row) {
+			rows[row] = true
+		}
+	}
+	t.selectionAnchor = -1
+	t.updateSelectedRows(rows)
+	return t
+}
+
+// SelectNoRows deselects all rows.
+func (t *Table) SelectNoRows() *Table {
+	t.selectionAnchor = -1
+	t.updateSelectedRows(nil)
+	return t
+}
+
+// rowSelectable returns whether or not the given row can be selected in
+// multi-select mode.
+func (t *Table) rowSelectable(row int) bool {
+	return row >= t.fixedRows && row < t.content.GetRowCount() && t.rowVisible(row)
+}
+
+// copySelectedRows returns a copy of the set of selected rows.
+func (t *Table) copySelectedRows() map[int]bool {
+	rows := make(map[int]bool, len(t.selectedRows))
+	for row := range t.selectedRows {
+		rows[row] = true
+	}
+	return rows
+}
+
+// updateSelectedRows replaces the set of selected rows. The "selected rows
+// changed" handler is called if the set changed.
+func (t *Table) updateSelectedRows(rows map[int]bool) {
+	changed := len(rows) != len(t.selectedRows)
+	for row := range rows {
+		if !t.selectedRows[row] {
+			changed = true
+			break
+		}
+	}
+	t.selectedRows = rows
+	if changed && t.selectedRowsChanged != nil {
+		t.selectedRowsChanged(t.GetSelectedRows())
+	}
+}
+
+// extendSelection selects all rows from the row where the user started
+// extending the selection (initially the given previously selected row) to
+// the currently selected row. Rows which were selected before the user
+// started extending the selection remain selected.
+func (t *Table) extendSelection(previousRow int) {
+	if t.selectionAnchor < 0 {
+		t.selectionAnchor = previousRow
+		t.selectionBase = t.copySelectedRows()
+	}
+	rows := make(map[int]bool, len(t.selectionBase))
+	for row := range t.selectionBase {
+		rows[row] = true
+	}
+	from, to := t.selectionAnchor, t.selectedRow
+	if from > to {
+		from, to = to, from
+	}
+	for row := from; row <= to; row++ {
+		if t.rowSelectable(row) {
+			rows[row] = true
+		}
+	}
+	t.updateSelectedRows(rows)
+}
+
+// selectionInput processes the keys which change the set of selected rows in
+// multi-select mode. The current selection was moved from the given row
+// before.
+func (t *Table) selectionInput(event *tcell.EventKey, previousRow int) {
+	switch event.Key() {
+	case tcell.KeyCtrlA:
+		t.SelectAllRows()
+	case tcell.KeyCtrlD:
+		t.SelectNoRows()
+	case tcell.KeyRune:
+		if event.Rune() == ' ' {
+			t.SetRowSelected(t.selectedRow, !t.selectedRows[t.selectedRow])
+		} else if t.selectedRow != previousRow {
+			t.selectionAnchor = -1
+		}
+	default:
+		if t.selectedRow != previousRow {
+			if event.Modifiers()&tcell.ModShift != 0 {
+				t.extendSelection(previousRow)
+			} else {
+				t.selectionAnchor = -1
+			}
+		}
+	}
+}
+
+// moveSelectedRows moves selected rows to new positions after rows were
+// inserted, removed, sorted, or hidden. The function returns the new position
+// of a row or a negative value if the row can no longer be selected.
+func (t *Table) moveSelectedRows(position func(row int) int) {
+	if len(t.selectedRows) == 0 {
+		return
+	}
+	rows := make(map[int]bool, len(t.selectedRows))
+	for row := range t.selectedRows {
+		if row = position(row); row >= 0 {
+			rows[row] = true
+		}
+	}
+	t.selectionAnchor = -1
+	t.updateSelectedRows(rows)
+}
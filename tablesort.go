@@ -0,0 +1,221 @@
+package tview
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TableSortFunc reports whether cell "a" sorts before cell "b". It is used to
+// sort the rows of a Table by the cells of one column, see
+// Table.SetSortFunc(). Cells which were never set are passed as empty cells.
+type TableSortFunc func(a, b *TableCell) bool
+
+// TableSortText compares the texts of two cells, without color tags and
+// ignoring case.
+func TableSortText(a, b *TableCell) bool {
+	return strings.ToLower(stripTags(a.Text)) < strings.ToLower(stripTags(b.Text))
+}
+
+// TableSortNumeric compares the texts of two cells as numbers. Cells which do
+// not contain a number sort after all numbers, in the order of
+// TableSortText().
+func TableSortNumeric(a, b *TableCell) bool {
+	numberA, errA := strconv.ParseFloat(strings.TrimSpace(stripTags(a.Text)), 64)
+	numberB, errB := strconv.ParseFloat(strings.TrimSpace(stripTags(b.Text)), 64)
+	switch {
+	case errA == nil && errB == nil:
+		return numberA < numberB
+	case errA == nil || errB == nil:
+		return errA == nil
+	}
+	return TableSortText(a, b)
+}
+
+// TableSortTime returns a function which compares the texts of two cells as
+// points in time in the given layout (see time.Parse()). Cells which cannot
+// be parsed sort after all others, in the order of TableSortText().
+func TableSortTime(layout string) TableSortFunc {
+	return func(a, b *TableCell) bool {
+		timeA, errA := time.Parse(layout, strings.TrimSpace(stripTags(a.Text)))
+		timeB, errB := time.Parse(layout, strings.TrimSpace(stripTags(b.Text)))
+		switch {
+		case errA == nil && errB == nil:
+			return timeA.Before(timeB)
+		case errA == nil || errB == nil:
+			return errA == nil
+		}
+		return TableSortText(a, b)
+	}
+}
+
+// SetSortable sets whether or not all columns of the table can be sorted by
+// the user. Columns without a sort function (see SetSortFunc()) are then
+// sorted with TableSortText().
+//
+// The user sorts the table by clicking on a cell in the fixed rows (see
+// SetFixed()) or by pressing Enter while such a cell is selected. This sorts
+// the table by the cell's column in ascending order or, if it is already
+// sorted by that column, reverses the order. Fixed rows are never sorted.
+// The user may also press "s" to sort by the next sortable column and "S" to
+// reverse the order, e.g. if the headers cannot be selected.
+func (t *Table) SetSortable(sortable bool) *Table {
+	t.sortable = sortable
+	return t
+}
+
+// SetSortFunc sets the function which is used to sort the table by the given
+// column, making the column sortable even if SetSortable() was not called.
+// Provide nil to remove the function.
+func (t *Table) SetSortFunc(column int, sorter TableSortFunc) *Table {
+	if t.sortFuncs == nil {
+		t.sortFuncs = make(map[int]TableSortFunc)
+	}
+	if sorter == nil {
+		delete(t.sortFuncs, column)
+	} else {
+		t.sortFuncs[column] = sorter
+	}
+	return t
+}
+
+// SetSortedFunc sets a handler which is called when the user sorted the
+// table. It receives the column by which the table is sorted and whether the
+// order is descending.
+func (t *Table) SetSortedFunc(handler func(column int, descending bool)) *Table {
+	t.sorted = handler
+	return t
+}
+
+// SortBy sorts all rows except the fixed rows by the cells of the given
+// column, using the column's sort function or TableSortText(). The order of
+// rows whose cells are equal is kept. The selected row keeps being selected,
+// even if it moves.
+//
+// Cells set afterwards are not sorted automatically. Call SortBy() again to
+// sort them. The sort indicator (see Styles.GraphicsSortAscending) is shown in
+// the last fixed row until ClearSort() is called.
+//
+// If the table's content was replaced with SetContent(), the table does not
+// reorder the rows itself. Sort the content in the handler set with
+// SetSortedFunc() instead.
+func (t *Table) SortBy(column int, descending bool) *Table {
+	t.sortColumn, t.sortDescending = column, descending
+	t.sortRows()
+	return t
+}
+
+// GetSortColumn returns the column by which the table was last sorted and
+// whether the order was descending. If the table is not sorted, -1 is
+// returned.
+func (t *Table) GetSortColumn() (column int, descending bool) {
+	return t.sortColumn, t.sortDescending
+}
+
+// ClearSort removes the sort indicator. The order of the rows is not changed.
+func (t *Table) ClearSort() *Table {
+	t.sortColumn, t.sortDescending = -1, false
+	return t
+}
+
+// sortFunc returns the function used to sort the table by the given column or
+// nil if the column cannot be sorted.
+func (t *Table) sortFunc(column int) TableSortFunc {
+	if sorter, ok := t.sortFuncs[column]; ok {
+		return sorter
+	}
+	if t.sortable {
+		return TableSortText
+	}
+	return nil
+}
+
+// toggleSort sorts the table by the given column, as if the user selected the
+// column's header. It returns false if the column cannot be sorted.
+func (t *Table) toggleSort(column int) bool {
+	if column < 0 || t.sortFunc(column) == nil {
+		return false
+	}
+	descending := column == t.sortColumn && !t.sortDescending
+	t.SortBy(column, descending)
+	if t.sorted != nil {
+		t.sorted(column, descending)
+	}
+	return true
+}
+
+// cycleSort sorts the table by the next sortable column after the current sort
+// column, in the order in which the columns are drawn, as if the user selected
+// that column's header. It returns false if no column can be sorted.
+func (t *Table) cycleSort() bool {
+	columns := t.columnIndices()
+	current := indexOf(columns, t.sortColumn)
+	for offset := 1; offset <= len(columns); offset++ {
+		if column := columns[(current+offset)%len(columns)]; t.sortFunc(column) != nil {
+			return t.toggleSort(column)
+		}
+	}
+	return false
+}
+
+// sortRows sorts the rows after the fixed rows according to the current sort
+// column.
+func (t *Table) sortRows() {
+	content, ok := t.content.(*tableDefaultContent)
+	if !ok || t.sortColumn < 0 || t.fixedRows >= len(content.cells) {
+		return
+	}
+	less := t.sortFunc(t.sortColumn)
+	if less == nil {
+		less = TableSortText
+	}
+
+	// Sort the row indices so the selected row can be found afterwards.
+	rows := make([]int, len(content.cells)-t.fixedRows)
+	for index := range rows {
+		rows[index] = t.fixedRows + index
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := t.GetCell(rows[i], t.sortColumn), t.GetCell(rows[j], t.sortColumn)
+		if t.sortDescending {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+
+	cells := make([][]*TableCell, len(rows))
+	selectedRow := t.selectedRow
+	for index, row := range rows {
+		cells[index] = content.cells[row]
+		if row == t.selectedRow {
+			selectedRow = t.fixedRows + index
+		}
+	}
+	copy(content.cells[t.fixedRows:], cells)
+	t.selectedRow = selectedRow
+
+	// Selected rows move with their cells.
+	positions := make(map[int]int, len(rows))
+	for index, row := range rows {
+		positions[row] = t.fixedRows + index
+	}
+	t.moveSelectedRows(func(row int) int {
+		if position, ok := positions[row]; ok {
+			return position
+		}
+		return row
+	})
+}
+
+// cellText returns the text drawn for the given cell. This is the cell's text
+// followed by the sort indicator for the header of the sort column.
+func (t *Table) cellText(row, column int, cell *TableCell) string {
+	if column != t.sortColumn || row != t.fixedRows-1 {
+		return cell.Text
+	}
+	if t.sortDescending {
+		return cell.Text + " " + string(Styles.GraphicsSortDescending)
+	}
+	return cell.Text + " " + string(Styles.GraphicsSortAscending)
+}
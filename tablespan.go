@@ -0,0 +1,194 @@
+package tview
+
+// tableSpan describes which cell covers a slot of the table the last time it
+// was drawn. Slots are referred to by their indices into the drawn rows and
+// columns.
+type tableSpan struct {
+	// The slot of the cell which covers this slot. For a slot which is not
+	// covered by another cell, this is the slot itself.
+	row, column int
+
+	// The number of drawn rows and columns covered by the cell in this slot.
+	// This is only set for slots which are not covered by another cell.
+	rows, columns int
+}
+
+// cellSpans determines which slots of the given drawn rows and columns are
+// covered by cells which span several rows or columns (see
+// TableCell.SetColumnSpan() and TableCell.SetRowSpan()). Cells do not span
+// across the boundary between fixed and scrolled rows or columns, nor do they
+// overlap cells which span themselves. The result is indexed by row first.
+func (t *Table) cellSpans(rows, columns []int) [][]tableSpan {
+	spans := make([][]tableSpan, len(rows))
+	for rowIndex := range rows {
+		spans[rowIndex] = make([]tableSpan, len(columns))
+		for columnIndex := range columns {
+			spans[rowIndex][columnIndex] = tableSpan{row: rowIndex, column: columnIndex, rows: 1, columns: 1}
+		}
+	}
+
+	// The number of fixed rows and columns which were drawn.
+	var fixedRows, fixedColumns int
+	for fixedRows < len(rows) && rows[fixedRows] < t.fixedRows {
+		fixedRows++
+	}
+	fixedColumns = t.fixedColumns
+	if fixedColumns > len(columns) {
+		fixedColumns = len(columns)
+	}
+
+	covered := func(rowIndex, columnIndex int) bool {
+		span := spans[rowIndex][columnIndex]
+		return span.row != rowIndex || span.column != columnIndex
+	}
+	for rowIndex, row := range rows {
+		for columnIndex, column := range columns {
+			if covered(rowIndex, columnIndex) {
+				continue
+			}
+			cell := t.content.GetCell(row, column)
+			if cell == nil || cell.RowSpan <= 1 && cell.ColumnSpan <= 1 {
+				continue
+			}
+
+			// How far does the cell extend?
+			lastRow, lastColumn := len(rows), len(columns)
+			if rowIndex < fixedRows {
+				lastRow = fixedRows
+			}
+			if columnIndex < fixedColumns {
+				lastColumn = fixedColumns
+			}
+			columnCount := 1
+			for columnCount < cell.ColumnSpan && columnIndex+columnCount < lastColumn && !covered(rowIndex, columnIndex+columnCount) {
+				columnCount++
+			}
+			rowCount := 1
+		RowLoop:
+			for rowCount < cell.RowSpan && rowIndex+rowCount < lastRow {
+				for offset := 0; offset < columnCount; offset++ {
+					if covered(rowIndex+rowCount, columnIndex+offset) {
+						break RowLoop
+					}
+				}
+				rowCount++
+			}
+
+			// Cover the slots.
+			for r := rowIndex; r < rowIndex+rowCount; r++ {
+				for c := columnIndex; c < columnIndex+columnCount; c++ {
+					spans[r][c] = tableSpan{row: rowIndex, column: columnIndex}
+				}
+			}
+			spans[rowIndex][columnIndex] = tableSpan{
+				row:     rowIndex,
+				column:  columnIndex,
+				rows:    rowCount,
+				columns: columnCount,
+			}
+		}
+	}
+	return spans
+}
+
+// spanWidths widens the given column widths such that the texts of cells
+// spanning several columns fit into them. (These cells are not considered when
+// the column widths are first determined.) The additional width is distributed
+// evenly across the spanned columns. It returns the total additional width.
+func (t *Table) spanWidths(rows, columns, widths []int, spans [][]tableSpan) (added int) {
+	for rowIndex, row := range rows {
+		for columnIndex, column := range columns {
+			span := spans[rowIndex][columnIndex]
+			if span.rows == 0 {
+				continue // This slot is covered by another cell.
+			}
+			cell := t.content.GetCell(row, column)
+			if cell == nil || cell.ColumnSpan <= 1 {
+				continue
+			}
+			_, _, _, _, cellWidth := decomposeString(t.cellText(row, column, cell))
+			if cell.MaxWidth > 0 && cell.MaxWidth < cellWidth {
+				cellWidth = cell.MaxWidth
+			}
+			available := span.columns - 1 // The separators between the columns.
+			for offset := 0; offset < span.columns; offset++ {
+				available += widths[columnIndex+offset]
+			}
+			missing := cellWidth - available
+			for offset := 0; missing > 0 && offset < span.columns; offset++ {
+				width := missing / (span.columns - offset)
+				if missing%(span.columns-offset) > 0 {
+					width++
+				}
+				widths[columnIndex+offset] += width
+				missing -= width
+				added += width
+			}
+		}
+	}
+	return
+}
+
+// pendingSpanWidth returns the width which spanWidths() adds to the given
+// column widths, which may be followed by more columns. Cells spanning past
+// the last of the given columns are therefore ignored. The widths are not
+// changed.
+func (t *Table) pendingSpanWidth(rows, columns, widths []int) int {
+	spans := t.cellSpans(rows, columns)
+	last := len(columns) - 1
+	for rowIndex, row := range rows {
+		for columnIndex, span := range spans[rowIndex] {
+			if span.rows == 0 || columnIndex+span.columns-1 != last || last+1 == t.fixedColumns {
+				continue // Covered, complete, or limited by the fixed columns.
+			}
+			if cell := t.content.GetCell(row, columns[columnIndex]); cell != nil && cell.ColumnSpan > span.columns {
+				spans[rowIndex][columnIndex].rows = 0 // Not complete yet.
+			}
+		}
+	}
+	return t.spanWidths(rows, columns, append([]int(nil), widths...), spans)
+}
+
+// drawnSlot returns the slot of the cell which covered the slot with the given
+// indices into the drawn rows and columns the last time the table was drawn.
+func (t *Table) drawnSlot(rowIndex, columnIndex int) tableSpan {
+	if rowIndex >= len(t.drawnSpans) || columnIndex >= len(t.drawnSpans[rowIndex]) {
+		return tableSpan{row: rowIndex, column: columnIndex}
+	}
+	span := t.drawnSpans[rowIndex][columnIndex]
+	return tableSpan{row: span.row, column: span.column}
+}
+
+// drawnSpan returns the span of the slot in which the cell at the given
+// position was drawn the last time the table was drawn. The second return
+// value is false if the cell was not drawn.
+func (t *Table) drawnSpan(row, column int) (span tableSpan, ok bool) {
+	rowIndex, columnIndex := indexOf(t.drawnRows, row), indexOf(t.drawnColumns, column)
+	if rowIndex < 0 || columnIndex < 0 || rowIndex >= len(t.drawnSpans) || columnIndex >= len(t.drawnSpans[rowIndex]) {
+		return
+	}
+	return t.drawnSpans[rowIndex][columnIndex], true
+}
+
+// spanOrigin returns the position of the cell which covered the cell at the
+// given position the last time the table was drawn. This is the position
+// itself if the cell was not covered by another cell or if it was not drawn.
+func (t *Table) spanOrigin(row, column int) (int, int) {
+	span, ok := t.drawnSpan(row, column)
+	if !ok {
+		return row, column
+	}
+	return t.drawnRows[span.row], t.drawnColumns[span.column]
+}
+
+// spanEnd returns the position of the last row and column covered by the cell
+// at the given position the last time the table was drawn. This is the
+// position itself if the cell did not span several rows or columns.
+func (t *Table) spanEnd(row, column int) (int, int) {
+	span, ok := t.drawnSpan(row, column)
+	if !ok || span.rows == 0 {
+		return row, column
+	}
+	rowIndex, columnIndex := indexOf(t.drawnRows, row), indexOf(t.drawnColumns, column)
+	return t.drawnRows[rowIndex+span.rows-1], t.drawnColumns[columnIndex+span.columns-1]
+}
@@ -0,0 +1,907 @@
+package tview
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell"
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// Edit actions of a TextArea, used to group changes for undo.
+const (
+	textAreaActionNone = iota
+	textAreaActionType
+	textAreaActionOther
+)
+
+// textAreaState is a snapshot of the text and cursor of a TextArea, used for
+// undo and redo.
+type textAreaState struct {
+	text   string
+	cursor int
+}
+
+// textAreaLine describes one line of text as it is shown on the screen, after
+// wrapping.
+type textAreaLine struct {
+	start, end int // The byte positions of the line's start and end.
+}
+
+// TextArea is a multi-line box where the user can enter and edit text. Lines
+// which are wider than the input area are wrapped, preferably at spaces.
+//
+// Like in an InputField, editing works on grapheme clusters (user-perceived
+// characters), so wide characters and emojis consisting of several code points
+// are inserted, deleted, and skipped as a whole. The following keys are supported:
+//
+//   - Left arrow, Right arrow: Move the cursor by one character.
+//   - Up arrow, Down arrow: Move the cursor by one (wrapped) line.
+//   - Home, End: Move the cursor to the start or end of the line.
+//   - Ctrl-Home, Ctrl-End: Move the cursor to the start or end of the text.
+//   - Page Up, Page Down: Move the cursor by one page.
+//   - Shift plus any of the keys above: Select text.
+//   - Enter: Insert a new line.
+//   - Backspace, Delete: Delete the selection or the character before or
+//     after the cursor.
+//   - Ctrl-Z, Ctrl-Y: Undo or redo the last change.
+//   - Tab, Backtab, Escape: Finish editing (see SetDoneFunc()).
+//
+// TextArea implements FormItem so it can be added to a Form. Use
+// SetFieldHeight() to set the number of rows it occupies.
+type TextArea struct {
+	*Box
+
+	align int
+
+	labelFiller string
+
+	lockColors bool
+
+	// The text that was entered.
+	text string
+
+	// The position of the cursor, as a byte position in "text".
+	cursor int
+
+	// The other end of the selection, as a byte position in "text", or a
+	// negative value if no text is selected.
+	selectionStart int
+
+	// The screen column the cursor should move to when moving up or down, or a
+	// negative value if the cursor's current column should be used.
+	preferredColumn int
+
+	// The undo and redo history.
+	undoStack, redoStack []textAreaState
+
+	// The last action which changed the text, used to group typed characters
+	// into one undo step.
+	lastAction int
+
+	// The maximum number of characters, 0 for no limit.
+	maxLength int
+
+	// The text to be displayed before the input area.
+	label string
+
+	// An optional name which identifies the item in a form, see
+	// Form.GetValues().
+	name string
+
+	// An additional label, drawn in front of "label" in its own color.
+	subLabel string
+
+	// The item sub label color.
+	subLabelColor tcell.Color
+
+	// The text to be displayed in the input area when "text" is empty.
+	placeholder string
+
+	// The label color.
+	labelColor tcell.Color
+
+	// The background color of the input area.
+	fieldBackgroundColor tcell.Color
+
+	// The text color of the input area.
+	fieldTextColor tcell.Color
+
+	// The background color of the input area when disabled.
+	fieldDisableBackgroundColor tcell.Color
+
+	// The text color of the input area when disabled.
+	fieldDisableTextColor tcell.Color
+
+	// The text color of the placeholder.
+	placeholderTextColor tcell.Color
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The screen width of the input area. A value of 0 means extend as much as
+	// possible.
+	fieldWidth int
+
+	// The index of the first line shown.
+	rowOffset int
+
+	// The position and size of the input area the last time the text area was
+	// drawn.
+	fieldX, fieldY, lastWidth, lastHeight int
+
+	// An optional function which is called when the input has changed.
+	changed func(text string)
+
+	// An optional function which is called when the user indicated that they
+	// are done entering text. The key which was pressed is provided (tab,
+	// shift-tab, or escape).
+	done func(tcell.Key)
+
+	// A callback function set by the Form class and called when the user leaves
+	// this form item.
+	finished func(tcell.Key)
+}
+
+// NewTextArea returns a new text area with three rows.
+func NewTextArea() *TextArea {
+	t := &TextArea{
+		Box:                         NewBox(),
+		selectionStart:              -1,
+		preferredColumn:             -1,
+		labelColor:                  Styles.LabelTextColor,
+		subLabelColor:               Styles.LabelTextColor,
+		fieldBackgroundColor:        Styles.FieldBackgroundColor,
+		fieldTextColor:              Styles.FieldTextColor,
+		placeholderTextColor:        Styles.ContrastSecondaryTextColor,
+		fieldDisableBackgroundColor: Styles.FieldDisableBackgroundColor,
+		fieldDisableTextColor:       Styles.FieldDisableTextColor,
+		align:                       AlignLeft,
+		labelFiller:                 " ",
+	}
+	t.height = 3
+	return t
+}
+
+// SetText sets the current text of the text area and moves the cursor to its
+// end. The undo history is cleared.
+func (t *TextArea) SetText(text string) *TextArea {
+	t.text = truncateRunes(text, t.maxLength)
+	t.cursor = len(t.text)
+	t.selectionStart = -1
+	t.preferredColumn = -1
+	t.undoStack, t.redoStack = nil, nil
+	t.lastAction = textAreaActionNone
+	if t.changed != nil {
+		t.changed(t.text)
+	}
+	return t
+}
+
+// GetText returns the current text of the text area.
+func (t *TextArea) GetText() string {
+	return t.text
+}
+
+// GetSelection returns the currently selected text and its start and end
+// positions (byte positions in the text, end exclusive). If no text is
+// selected, an empty string is returned and start and end are both the cursor
+// position.
+func (t *TextArea) GetSelection() (text string, start, end int) {
+	start, end = t.selectionRange()
+	return t.text[start:end], start, end
+}
+
+// Select selects the text between the given byte positions in the text. The
+// cursor is placed at "end". Positions inside a grapheme cluster are moved to
+// its start.
+func (t *TextArea) Select(start, end int) *TextArea {
+	clusters := t.clusters()
+	t.selectionStart = clusters.snap(start)
+	t.cursor = clusters.snap(end)
+	t.preferredColumn = -1
+	return t
+}
+
+// SetLockColors locks the change of colors by form
+func (t *TextArea) SetLockColors(lock bool) *TextArea {
+	t.lockColors = lock
+	return t
+}
+
+// SetLabel sets the text to be displayed before the input area.
+func (t *TextArea) SetLabel(label string) *TextArea {
+	if !strings.Contains(label, "%s") {
+		label += "%s"
+	}
+	t.label = label
+	return t
+}
+
+// GetLabel returns the text to be displayed before the input area.
+func (t *TextArea) GetLabel() string {
+	return t.label
+}
+
+// SetName sets a name which identifies the item in a form. It is used instead
+// of the label as the key in Form.GetValues() and Form.SetValues().
+func (t *TextArea) SetName(name string) *TextArea {
+	t.name = name
+	return t
+}
+
+// GetName returns the name set with SetName().
+func (t *TextArea) GetName() string {
+	return t.name
+}
+
+// GetValue returns the text of the text area. See FormItemValue.
+func (t *TextArea) GetValue() interface{} {
+	return t.GetText()
+}
+
+// SetValue sets the text of the text area. Values which are not strings are
+// formatted with fmt.Sprint(), nil clears the text. See FormItemValue.
+func (t *TextArea) SetValue(value interface{}) error {
+	t.SetText(formValueText(value))
+	return nil
+}
+
+// GetLabelWidth returns label width.
+func (t *TextArea) GetLabelWidth() int {
+	return StringWidth(strings.Replace(t.subLabel+t.label, "%s", "", -1))
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (t *TextArea) SetLabelWidth(width int) *TextArea {
+	t.labelWidth = width
+	return t
+}
+
+// SetPlaceholder sets the text to be displayed when the text area is empty.
+func (t *TextArea) SetPlaceholder(text string) *TextArea {
+	t.placeholder = text
+	return t
+}
+
+// SetMaxLength sets the maximum number of characters which can be entered. A
+// value of 0 means there is no limit.
+func (t *TextArea) SetMaxLength(maxLength int) *TextArea {
+	t.maxLength = maxLength
+	return t
+}
+
+// SetLabelColor sets the color of the label.
+func (t *TextArea) SetLabelColor(color tcell.Color) *TextArea {
+	t.labelColor = color
+	return t
+}
+
+// SetSubLabel sets the text to be displayed before the input area.
+func (t *TextArea) SetSubLabel(label string) *TextArea {
+	t.subLabel = label
+	return t
+}
+
+// SetSubLabelColor sets the color of the subLabel.
+func (t *TextArea) SetSubLabelColor(color tcell.Color) *TextArea {
+	t.subLabelColor = color
+	return t
+}
+
+// SetFieldBackgroundColor sets the background color of the input area.
+func (t *TextArea) SetFieldBackgroundColor(color tcell.Color) *TextArea {
+	t.fieldBackgroundColor = color
+	return t
+}
+
+// SetFieldTextColor sets the text color of the input area.
+func (t *TextArea) SetFieldTextColor(color tcell.Color) *TextArea {
+	t.fieldTextColor = color
+	return t
+}
+
+// SetPlaceholderTextColor sets the text color of placeholder text.
+func (t *TextArea) SetPlaceholderTextColor(color tcell.Color) *TextArea {
+	t.placeholderTextColor = color
+	return t
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (t *TextArea) SetFormAttributes(labelWidth, fieldWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	if t.fieldWidth == 0 {
+		t.fieldWidth = fieldWidth
+	}
+	if t.labelWidth == 0 {
+		t.labelWidth = labelWidth
+	}
+
+	if !t.lockColors {
+		t.labelColor = labelColor
+		t.backgroundColor = bgColor
+		t.fieldTextColor = fieldTextColor
+		t.fieldBackgroundColor = fieldBgColor
+	}
+	return t
+}
+
+// SetFieldAlign sets the input alignment within the form. This must be either
+// AlignLeft, AlignCenter, or AlignRight.
+func (t *TextArea) SetFieldAlign(align int) FormItem {
+	t.align = align
+	return t
+}
+
+// GetFieldAlign returns the input alignment within the form.
+func (t *TextArea) GetFieldAlign() (align int) {
+	return t.align
+}
+
+// SetFieldWidth sets the screen width of the input area. A value of 0 means
+// extend as much as possible.
+func (t *TextArea) SetFieldWidth(width int) *TextArea {
+	t.fieldWidth = width
+	return t
+}
+
+// GetFieldWidth returns this primitive's field width.
+func (t *TextArea) GetFieldWidth() int {
+	return t.fieldWidth
+}
+
+// SetFieldHeight sets the number of rows of the input area. This is the
+// height the text area occupies in a Form.
+func (t *TextArea) SetFieldHeight(height int) *TextArea {
+	t.height = height
+	return t
+}
+
+// GetFieldHeight returns the number of rows of the input area.
+func (t *TextArea) GetFieldHeight() int {
+	return t.height
+}
+
+// SetChangedFunc sets a handler which is called whenever the text of the text
+// area has changed. It receives the current text (after the change).
+func (t *TextArea) SetChangedFunc(handler func(text string)) *TextArea {
+	t.changed = handler
+	return t
+}
+
+// SetDoneFunc sets a handler which is called when the user is done entering
+// text. The callback function is provided with the key that was pressed, which
+// is one of the following:
+//
+//   - KeyEscape: Abort text input.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+func (t *TextArea) SetDoneFunc(handler func(key tcell.Key)) *TextArea {
+	t.done = handler
+	return t
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (t *TextArea) SetFinishedFunc(handler func(key tcell.Key)) FormItem {
+	t.finished = handler
+	return t
+}
+
+// GetFinishedFunc returns SetDoneFunc().
+func (t *TextArea) GetFinishedFunc() func(key tcell.Key) {
+	return t.finished
+}
+
+// Focus is called when this primitive receives focus.
+func (t *TextArea) Focus(delegate func(p Primitive)) {
+	if t.disable {
+		return
+	}
+	t.hasFocus = true
+}
+
+// clamp returns the given text position, limited to the valid range.
+func (t *TextArea) clamp(position int) int {
+	if position < 0 {
+		return 0
+	}
+	if position > len(t.text) {
+		return len(t.text)
+	}
+	return position
+}
+
+// selectionRange returns the start and end (exclusive) of the selected text.
+// If nothing is selected, both are the cursor position.
+func (t *TextArea) selectionRange() (start, end int) {
+	if t.selectionStart < 0 || t.selectionStart == t.cursor {
+		return t.cursor, t.cursor
+	}
+	if t.selectionStart < t.cursor {
+		return t.selectionStart, t.cursor
+	}
+	return t.cursor, t.selectionStart
+}
+
+// clusters splits the text area's text into grapheme clusters.
+func (t *TextArea) clusters() textClusters {
+	return splitClusters(t.text)
+}
+
+// truncateRunes returns the longest prefix of the given text which consists
+// of no more than "maxLength" runes and does not end inside a grapheme
+// cluster. A maximum length of 0 or less means there is no limit.
+func truncateRunes(text string, maxLength int) string {
+	if maxLength <= 0 || utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	position := 0
+	for count := 0; count < maxLength; count++ {
+		_, size := utf8.DecodeRuneInString(text[position:])
+		position += size
+	}
+	return text[:splitClusters(text).snap(position)]
+}
+
+// wrap splits the text into lines no wider than the given screen width. Lines
+// are broken after spaces if possible, but never inside a grapheme cluster. A
+// line which fills the entire width is followed by an empty line so that the
+// cursor can be placed at its end.
+func (t *TextArea) wrap(width int) (lines []textAreaLine) {
+	if width < 1 {
+		width = 1
+	}
+	clusters := t.clusters()
+	position := func(index int) int {
+		if index < len(clusters) {
+			return clusters[index].start
+		}
+		return len(t.text)
+	}
+
+	// Indices below refer to clusters.
+	start, lineWidth, lastSpace := 0, 0, -1
+	for index := 0; index <= len(clusters); index++ {
+		if index == len(clusters) || clusters[index].runes[len(clusters[index].runes)-1] == '\n' { // Includes "\r\n".
+			lines = append(lines, textAreaLine{start: position(start), end: position(index)})
+			if lineWidth >= width {
+				lines = append(lines, textAreaLine{start: position(index), end: position(index)})
+			}
+			start, lineWidth, lastSpace = index+1, 0, -1
+			continue
+		}
+		cluster := clusters[index]
+		if lineWidth+cluster.width > width && index > start {
+			// Break the line here or after the last space.
+			breakAt := index
+			if lastSpace >= start && lastSpace+1 < index {
+				breakAt = lastSpace + 1
+			}
+			lines = append(lines, textAreaLine{start: position(start), end: position(breakAt)})
+			start, lineWidth, lastSpace = breakAt, 0, -1
+			for _, c := range clusters[breakAt:index] {
+				lineWidth += c.width
+			}
+		}
+		lineWidth += cluster.width
+		if len(cluster.runes) == 1 && cluster.runes[0] == ' ' {
+			lastSpace = index
+		}
+	}
+	return
+}
+
+// lineOf returns the index of the line which contains the given position.
+func lineOf(lines []textAreaLine, position int) int {
+	for index := len(lines) - 1; index > 0; index-- {
+		if lines[index].start <= position {
+			return index
+		}
+	}
+	return 0
+}
+
+// column returns the screen column of the given position within its line.
+func (t *TextArea) column(line textAreaLine, position int) int {
+	return t.clusters().width(line.start, position)
+}
+
+// positionAt returns the position in the given line which is closest to the
+// given screen column.
+func (t *TextArea) positionAt(line textAreaLine, column int) int {
+	x := 0
+	for _, cluster := range t.clusters() {
+		if cluster.start < line.start {
+			continue
+		}
+		if cluster.start >= line.end {
+			break
+		}
+		if x+cluster.width > column {
+			return cluster.start
+		}
+		x += cluster.width
+	}
+	return line.end
+}
+
+// lines returns the wrapped lines using the width of the last draw.
+func (t *TextArea) lines() []textAreaLine {
+	width := t.lastWidth
+	if width <= 0 {
+		width = math.MaxInt32
+	}
+	return t.wrap(width)
+}
+
+// pushUndo saves the current state before a change of the given kind. Typed
+// characters are grouped into one undo step.
+func (t *TextArea) pushUndo(action int) {
+	if action != textAreaActionType || t.lastAction != textAreaActionType {
+		state := textAreaState{
+			text:   t.text,
+			cursor: t.cursor,
+		}
+		t.undoStack = append(t.undoStack, state)
+	}
+	t.redoStack = nil
+	t.lastAction = action
+}
+
+// restore replaces the current state with a state from the undo or redo stack
+// and pushes the current state onto the other stack.
+func (t *TextArea) restore(from, to *[]textAreaState) {
+	if len(*from) == 0 {
+		return
+	}
+	*to = append(*to, textAreaState{text: t.text, cursor: t.cursor})
+	state := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	t.text, t.cursor = state.text, state.cursor
+	t.selectionStart = -1
+	t.preferredColumn = -1
+	t.lastAction = textAreaActionNone
+}
+
+// Undo reverts the last change made by the user.
+func (t *TextArea) Undo() *TextArea {
+	t.restore(&t.undoStack, &t.redoStack)
+	return t
+}
+
+// Redo reapplies the last change reverted with Undo().
+func (t *TextArea) Redo() *TextArea {
+	t.restore(&t.redoStack, &t.undoStack)
+	return t
+}
+
+// replaceSelection replaces the selected text (or inserts at the cursor if no
+// text is selected) with the given text, respecting the maximum length. It
+// returns false if nothing was changed.
+func (t *TextArea) replaceSelection(text string, action int) bool {
+	start, end := t.selectionRange()
+	if t.maxLength > 0 {
+		available := t.maxLength - utf8.RuneCountInString(t.text) + utf8.RuneCountInString(t.text[start:end])
+		if available <= 0 {
+			text = ""
+		} else {
+			text = truncateRunes(text, available)
+		}
+	}
+	if start == end && len(text) == 0 {
+		return false
+	}
+	t.pushUndo(action)
+	t.text = t.text[:start] + text + t.text[end:]
+	t.cursor = start + len(text)
+	t.selectionStart = -1
+	t.preferredColumn = -1
+	return true
+}
+
+// Draw draws this primitive onto the screen.
+func (t *TextArea) Draw(screen tcell.Screen) {
+	t.Box.Draw(screen)
+
+	fieldBackgroundColor := t.fieldBackgroundColor
+	fieldTextColor := t.fieldTextColor
+	if t.disable {
+		fieldBackgroundColor = t.fieldDisableBackgroundColor
+		fieldTextColor = t.fieldDisableTextColor
+	}
+
+	// Prepare
+	x, y, width, height := t.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	var labels = []struct {
+		text  string
+		color tcell.Color
+	}{{
+		text:  t.subLabel,
+		color: t.subLabelColor,
+	}, {
+		text:  t.label,
+		color: t.labelColor,
+	}}
+
+	if len(labels) > 0 {
+		labelWidth := t.labelWidth
+		if labelWidth > rightLimit-x {
+			labelWidth = rightLimit - x
+		}
+
+		addCount := labelWidth - t.GetLabelWidth()
+
+		for _, label := range labels {
+			if addCount > 0 && strings.Contains(label.text, "%s") {
+				label.text = fmt.Sprintf(label.text, strings.Repeat(t.labelFiller, addCount))
+				addCount = 0
+			} else {
+				label.text = strings.Replace(label.text, "%s", "", -1)
+			}
+
+			labelWidth = StringWidth(label.text)
+			Print(screen, label.text, x, y, labelWidth, AlignLeft, label.color)
+			x += labelWidth
+		}
+		x++
+	}
+
+	// Determine the input area.
+	fieldWidth := t.fieldWidth
+	if fieldWidth == 0 || rightLimit-x < fieldWidth {
+		fieldWidth = rightLimit - x
+	}
+	if fieldWidth < 1 {
+		return
+	}
+	t.fieldX, t.fieldY, t.lastWidth, t.lastHeight = x, y, fieldWidth, height
+	fieldStyle := tcell.StyleDefault.Background(fieldBackgroundColor).Foreground(fieldTextColor)
+	selectedStyle := tcell.StyleDefault.Background(fieldTextColor).Foreground(fieldBackgroundColor)
+	for row := 0; row < height; row++ {
+		for column := 0; column < fieldWidth; column++ {
+			screen.SetContent(x+column, y+row, ' ', nil, fieldStyle)
+		}
+	}
+
+	// Draw placeholder text.
+	if len(t.text) == 0 && t.placeholder != "" {
+		for row, line := range WordWrap(t.placeholder, fieldWidth) {
+			if row >= height {
+				break
+			}
+			Print(screen, line, x, y+row, fieldWidth, AlignLeft, t.placeholderTextColor)
+		}
+	}
+
+	// Keep the cursor visible.
+	clusters := t.clusters()
+	t.cursor = clusters.snap(t.cursor)
+	lines := t.wrap(fieldWidth)
+	cursorLine := lineOf(lines, t.cursor)
+	if cursorLine < t.rowOffset {
+		t.rowOffset = cursorLine
+	} else if cursorLine >= t.rowOffset+height {
+		t.rowOffset = cursorLine - height + 1
+	}
+	if t.rowOffset > len(lines)-height {
+		t.rowOffset = len(lines) - height
+	}
+	if t.rowOffset < 0 {
+		t.rowOffset = 0
+	}
+
+	// Draw the text.
+	selectionStart, selectionEnd := t.selectionRange()
+	for row := 0; row < height && t.rowOffset+row < len(lines); row++ {
+		line := lines[t.rowOffset+row]
+		column := 0
+		for _, cluster := range clusters {
+			if cluster.start < line.start {
+				continue
+			}
+			if cluster.start >= line.end || column+cluster.width > fieldWidth {
+				break
+			}
+			style := fieldStyle
+			if cluster.start >= selectionStart && cluster.start < selectionEnd {
+				style = selectedStyle
+			}
+			r, combining := cluster.runes[0], cluster.runes[1:]
+			if runewidth.RuneWidth(r) < 1 {
+				r, combining = ' ', nil
+			}
+			screen.SetContent(x+column, y+row, r, combining, style)
+			column += cluster.width
+		}
+	}
+
+	// Set cursor.
+	if !t.disable && t.focus.HasFocus() {
+		screen.ShowCursor(x+clusters.width(lines[cursorLine].start, t.cursor), y+cursorLine-t.rowOffset)
+	}
+}
+
+// moveCursor moves the cursor to the given position. If "selecting" is true,
+// the selection is extended, otherwise it is cleared.
+func (t *TextArea) moveCursor(position int, selecting bool) {
+	if selecting {
+		if t.selectionStart < 0 {
+			t.selectionStart = t.cursor
+		}
+	} else {
+		t.selectionStart = -1
+	}
+	t.cursor = t.clamp(position)
+	t.lastAction = textAreaActionNone
+}
+
+// moveLines moves the cursor up (negative) or down (positive) by the given
+// number of lines, keeping its screen column if possible.
+func (t *TextArea) moveLines(delta int, selecting bool) {
+	lines := t.lines()
+	current := lineOf(lines, t.cursor)
+	if t.preferredColumn < 0 {
+		t.preferredColumn = t.column(lines[current], t.cursor)
+	}
+	column := t.preferredColumn
+	target := current + delta
+	switch {
+	case target < 0:
+		t.moveCursor(0, selecting)
+	case target >= len(lines):
+		t.moveCursor(len(t.text), selecting)
+	default:
+		t.moveCursor(t.positionAt(lines[target], column), selecting)
+	}
+	t.preferredColumn = column
+}
+
+// InputHandler returns the handler for this primitive.
+func (t *TextArea) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if t.disable {
+			return
+		}
+
+		// Trigger changed events.
+		currentText := t.text
+		defer func() {
+			if t.changed != nil && t.text != currentText {
+				t.changed(t.text)
+			}
+		}()
+
+		selecting := event.Modifiers()&tcell.ModShift != 0
+		ctrl := event.Modifiers()&tcell.ModCtrl != 0
+		preferredColumn := -1
+		pageSize := t.lastHeight
+		if pageSize < 1 {
+			pageSize = 1
+		}
+
+		// Process key event.
+		switch key := event.Key(); key {
+		case tcell.KeyRune: // Regular character.
+			t.replaceSelection(string(event.Rune()), textAreaActionType)
+		case tcell.KeyEnter: // New line.
+			t.replaceSelection("\n", textAreaActionOther)
+		case tcell.KeyBackspace, tcell.KeyBackspace2: // Delete the previous character.
+			if start, end := t.selectionRange(); start == end && t.cursor > 0 {
+				t.selectionStart = t.clusters().previous(t.cursor)
+			}
+			t.replaceSelection("", textAreaActionOther)
+		case tcell.KeyDelete: // Delete the next character.
+			if start, end := t.selectionRange(); start == end && t.cursor < len(t.text) {
+				t.selectionStart = t.clusters().next(t.cursor)
+			}
+			t.replaceSelection("", textAreaActionOther)
+		case tcell.KeyCtrlZ:
+			t.Undo()
+		case tcell.KeyCtrlY:
+			t.Redo()
+		case tcell.KeyLeft:
+			if start, end := t.selectionRange(); start != end && !selecting {
+				t.moveCursor(start, false)
+				break
+			}
+			t.moveCursor(t.clusters().previous(t.cursor), selecting)
+		case tcell.KeyRight:
+			if start, end := t.selectionRange(); start != end && !selecting {
+				t.moveCursor(end, false)
+				break
+			}
+			t.moveCursor(t.clusters().next(t.cursor), selecting)
+		case tcell.KeyUp:
+			t.moveLines(-1, selecting)
+			preferredColumn = t.preferredColumn
+		case tcell.KeyDown:
+			t.moveLines(1, selecting)
+			preferredColumn = t.preferredColumn
+		case tcell.KeyPgUp:
+			t.moveLines(-pageSize, selecting)
+			preferredColumn = t.preferredColumn
+		case tcell.KeyPgDn:
+			t.moveLines(pageSize, selecting)
+			preferredColumn = t.preferredColumn
+		case tcell.KeyHome:
+			if ctrl {
+				t.moveCursor(0, selecting)
+				break
+			}
+			lines := t.lines()
+			t.moveCursor(lines[lineOf(lines, t.cursor)].start, selecting)
+		case tcell.KeyEnd:
+			if ctrl {
+				t.moveCursor(len(t.text), selecting)
+				break
+			}
+			lines := t.lines()
+			t.moveCursor(lines[lineOf(lines, t.cursor)].end, selecting)
+		case tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape: // We're done.
+			t.selectionStart = -1
+			if t.done != nil {
+				t.done(key)
+			}
+			if t.finished != nil {
+				t.finished(key)
+			}
+		}
+		t.preferredColumn = preferredColumn
+	})
+}
+
+// positionAtPoint returns the text position closest to the given screen
+// position, based on the last time the text area was drawn.
+func (t *TextArea) positionAtPoint(x, y int) int {
+	lines := t.lines()
+	row := y - t.fieldY + t.rowOffset
+	if row < 0 {
+		return 0
+	}
+	if row >= len(lines) {
+		return len(t.text)
+	}
+	return t.positionAt(lines[row], x-t.fieldX)
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (t *TextArea) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		x, y := event.Position()
+		if t.disable || !t.InRect(x, y) {
+			return false
+		}
+
+		// Process mouse event.
+		switch action {
+		case MouseLeftDown:
+			setFocus(t)
+			t.moveCursor(t.positionAtPoint(x, y), false)
+			t.preferredColumn = -1
+			consumed = true
+		case MouseMove:
+			if event.Buttons()&tcell.Button1 != 0 {
+				// Dragging selects text.
+				t.moveCursor(t.positionAtPoint(x, y), true)
+				t.preferredColumn = -1
+				consumed = true
+			}
+		case MouseScrollUp:
+			t.moveLines(-1, false)
+			consumed = true
+		case MouseScrollDown:
+			t.moveLines(1, false)
+			consumed = true
+		}
+		return
+	})
+}
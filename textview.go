@@ -986,6 +986,34 @@ func (t *TextView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 	})
 }
 
+// MouseHandler returns the mouse handler for this primitive.
+func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		if !t.InRect(event.Position()) {
+			return false
+		}
+
+		// Process mouse event.
+		switch action {
+		case MouseLeftDown:
+			setFocus(t)
+			consumed = true
+		case MouseScrollUp:
+			if t.scrollable {
+				t.trackEnd = false
+				t.lineOffset--
+				consumed = true
+			}
+		case MouseScrollDown:
+			if t.scrollable {
+				t.lineOffset++
+				consumed = true
+			}
+		}
+		return
+	})
+}
+
 // GetLabel returns the text to be displayed before the input area.
 func (t *TextView) GetLabel() string {
 	return strings.Join(t.buffer, " ")
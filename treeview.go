@@ -0,0 +1,711 @@
+package tview
+
+import (
+	"github.com/gdamore/tcell"
+)
+
+// TreeNode represents one node in a TreeView. A node has a text, an optional
+// reference to arbitrary data, and optional child nodes. Child nodes may also
+// be loaded only when the node is expanded for the first time, see
+// SetLoadFunc().
+type TreeNode struct {
+	// The text to be displayed.
+	text string
+
+	// Any additional data to be associated with this node.
+	reference interface{}
+
+	// The node's parent node, nil for the root node.
+	parent *TreeNode
+
+	// The child nodes.
+	children []*TreeNode
+
+	// Whether or not the child nodes are visible.
+	expanded bool
+
+	// The text color.
+	color tcell.Color
+
+	// An optional function which is called when the node is expanded for the
+	// first time. It returns the child nodes to be added.
+	load func(node *TreeNode) []*TreeNode
+
+	// Whether or not the load function was called.
+	loaded bool
+
+	// An optional function which is called when the user selects this node.
+	selected func()
+
+	// Temporary member variables, set when the tree view is drawn.
+	level  int    // The node's level, 0 for the root node.
+	prefix string // The guide lines drawn in front of the node.
+}
+
+// NewTreeNode returns a new collapsed tree node with the given text and no
+// children.
+func NewTreeNode(text string) *TreeNode {
+	return &TreeNode{
+		text:  text,
+		color: Styles.PrimaryTextColor,
+	}
+}
+
+// SetText sets the node's text which is displayed. It may contain color tags.
+func (n *TreeNode) SetText(text string) *TreeNode {
+	n.text = text
+	return n
+}
+
+// GetText returns the node's text.
+func (n *TreeNode) GetText() string {
+	return n.text
+}
+
+// SetReference allows you to store a reference of any type in this node. This
+// will allow you to establish a mapping between the TreeView hierarchy and
+// your internal tree structure.
+func (n *TreeNode) SetReference(reference interface{}) *TreeNode {
+	n.reference = reference
+	return n
+}
+
+// GetReference returns this node's reference object.
+func (n *TreeNode) GetReference() interface{} {
+	return n.reference
+}
+
+// SetColor sets the node's text color.
+func (n *TreeNode) SetColor(color tcell.Color) *TreeNode {
+	n.color = color
+	return n
+}
+
+// GetColor returns the node's text color.
+func (n *TreeNode) GetColor() tcell.Color {
+	return n.color
+}
+
+// GetParent returns the node's parent node or nil if this is a root node or if
+// the node was not added to any other node.
+func (n *TreeNode) GetParent() *TreeNode {
+	return n.parent
+}
+
+// SetChildren replaces this node's children with the given nodes.
+func (n *TreeNode) SetChildren(children []*TreeNode) *TreeNode {
+	n.children = nil
+	for _, child := range children {
+		n.AddChild(child)
+	}
+	return n
+}
+
+// GetChildren returns this node's children.
+func (n *TreeNode) GetChildren() []*TreeNode {
+	return n.children
+}
+
+// AddChild adds a new child node to this node.
+func (n *TreeNode) AddChild(node *TreeNode) *TreeNode {
+	node.parent = n
+	n.children = append(n.children, node)
+	return n
+}
+
+// RemoveChild removes the given node from this node's children. Nothing
+// happens if it is not a child of this node.
+func (n *TreeNode) RemoveChild(node *TreeNode) *TreeNode {
+	for index, child := range n.children {
+		if child == node {
+			n.children = append(n.children[:index], n.children[index+1:]...)
+			node.parent = nil
+			break
+		}
+	}
+	return n
+}
+
+// ClearChildren removes all child nodes from this node. If a load function
+// was set (see SetLoadFunc()), it will be called again when the node is
+// expanded the next time.
+func (n *TreeNode) ClearChildren() *TreeNode {
+	for _, child := range n.children {
+		child.parent = nil
+	}
+	n.children = nil
+	n.loaded = false
+	return n
+}
+
+// SetLoadFunc sets a function which is called when this node is expanded for
+// the first time. The nodes it returns are added as children of this node. Use
+// this to load large or expensive hierarchies only when they are needed.
+//
+// A node with a load function which has not been called yet is considered to
+// have children. The function may also return nil and add children later, e.g.
+// from another goroutine via Application.QueueUpdateDraw().
+func (n *TreeNode) SetLoadFunc(handler func(node *TreeNode) []*TreeNode) *TreeNode {
+	n.load = handler
+	n.loaded = false
+	return n
+}
+
+// SetSelectedFunc sets a function which is called when the user selects this
+// node by pressing Enter when it is the current node.
+func (n *TreeNode) SetSelectedFunc(handler func()) *TreeNode {
+	n.selected = handler
+	return n
+}
+
+// IsExpandable returns whether or not this node has children or will load
+// them when it is expanded.
+func (n *TreeNode) IsExpandable() bool {
+	return len(n.children) > 0 || n.load != nil && !n.loaded
+}
+
+// SetExpanded sets whether or not this node's child nodes are visible.
+// Expanding a node calls its load function if needed.
+func (n *TreeNode) SetExpanded(expanded bool) *TreeNode {
+	if expanded {
+		return n.Expand()
+	}
+	return n.Collapse()
+}
+
+// IsExpanded returns whether or not this node's child nodes are visible.
+func (n *TreeNode) IsExpanded() bool {
+	return n.expanded
+}
+
+// Expand makes this node's child nodes visible. If a load function was set and
+// has not been called yet, it is called first.
+func (n *TreeNode) Expand() *TreeNode {
+	if n.load != nil && !n.loaded {
+		n.loaded = true
+		for _, child := range n.load(n) {
+			n.AddChild(child)
+		}
+	}
+	n.expanded = true
+	return n
+}
+
+// Collapse hides this node's child nodes.
+func (n *TreeNode) Collapse() *TreeNode {
+	n.expanded = false
+	return n
+}
+
+// ExpandAll expands this node and all descendant nodes. Note that this calls
+// all load functions encountered along the way.
+func (n *TreeNode) ExpandAll() *TreeNode {
+	n.Walk(func(node, parent *TreeNode) bool {
+		node.Expand()
+		return true
+	})
+	return n
+}
+
+// CollapseAll collapses this node and all descendant nodes.
+func (n *TreeNode) CollapseAll() *TreeNode {
+	n.Walk(func(node, parent *TreeNode) bool {
+		node.expanded = false
+		return true
+	})
+	return n
+}
+
+// Walk traverses this node's subtree in depth-first, pre-order (NLR) order and
+// calls the provided callback function on each traversed node (which includes
+// this node) with the traversed node and its parent node (nil for this node).
+// The callback returns whether traversal should continue with the traversed
+// node's child nodes (true) or not recurse any deeper (false).
+func (n *TreeNode) Walk(callback func(node, parent *TreeNode) bool) *TreeNode {
+	n.walk(nil, callback)
+	return n
+}
+
+// walk implements Walk().
+func (n *TreeNode) walk(parent *TreeNode, callback func(node, parent *TreeNode) bool) {
+	if !callback(n, parent) {
+		return
+	}
+	for _, child := range n.children {
+		child.walk(n, callback)
+	}
+}
+
+// TreeView displays tree structures. A tree consists of nodes (TreeNode
+// objects) where each node has zero or more child nodes and exactly one parent
+// node (except for the root node which has no parent node).
+//
+// The tree is drawn with guide lines (using the Styles.Graphics* runes) which
+// connect nodes to their parents. Nodes which have children or which will load
+// them on demand are preceded by a marker that indicates whether they are
+// expanded or collapsed.
+//
+// Navigation is similar to a List:
+//
+//   - Up arrow, Down arrow: Move to the previous or next visible node.
+//   - Left arrow: Collapse the current node or, if it is collapsed, move to its
+//     parent node.
+//   - Right arrow: Expand the current node or, if it is expanded, move to its
+//     first child node.
+//   - +, -: Expand or collapse the current node.
+//   - Space: Toggle the expansion of the current node.
+//   - Home, End: Move to the first or last visible node.
+//   - Page Up, Page Down: Move up or down one page.
+//   - Enter: Select the current node.
+//   - Escape: Call the "done" handler.
+type TreeView struct {
+	*Box
+
+	// The root node.
+	root *TreeNode
+
+	// The currently selected node or nil if no node is selected.
+	currentNode *TreeNode
+
+	// The nodes visible the last time the tree was processed, in the order
+	// they are drawn.
+	nodes []*TreeNode
+
+	// The index of the first node shown the last time the tree was drawn.
+	offset int
+
+	// The height of the tree's inner rectangle the last time it was drawn.
+	pageSize int
+
+	// Whether or not guide lines are drawn.
+	graphics bool
+
+	// The color of the guide lines.
+	graphicsColor tcell.Color
+
+	// The markers shown in front of collapsed and expanded nodes.
+	collapsedMarker, expandedMarker string
+
+	// The text color for selected nodes.
+	selectedTextColor tcell.Color
+
+	// The background color for selected nodes.
+	selectedBackgroundColor tcell.Color
+
+	// An optional function which is called when the user has navigated to a
+	// node.
+	changed func(node *TreeNode)
+
+	// An optional function which is called when a node was selected. This
+	// function will be called even if the node defines its own callback.
+	selected func(node *TreeNode)
+
+	// An optional function which is called when the user presses the Escape key.
+	done func()
+}
+
+// NewTreeView returns a new tree view.
+func NewTreeView() *TreeView {
+	return &TreeView{
+		Box:                     NewBox(),
+		graphics:                true,
+		graphicsColor:           Styles.GraphicsColor,
+		collapsedMarker:         "+",
+		expandedMarker:          "-",
+		selectedTextColor:       Styles.PrimitiveBackgroundColor,
+		selectedBackgroundColor: Styles.PrimaryTextColor,
+	}
+}
+
+// SetRoot sets the root node of the tree. The root node becomes the current
+// node. This triggers a "changed" event.
+func (t *TreeView) SetRoot(root *TreeNode) *TreeView {
+	t.root = root
+	t.offset = 0
+	return t.SetCurrentNode(root)
+}
+
+// GetRoot returns the root node of the tree. If no such node was previously
+// set, nil is returned.
+func (t *TreeView) GetRoot() *TreeNode {
+	return t.root
+}
+
+// SetCurrentNode sets the currently selected node. Provide nil to clear the
+// selection. The node's ancestors are expanded so that it becomes visible. This
+// triggers a "changed" event.
+func (t *TreeView) SetCurrentNode(node *TreeNode) *TreeView {
+	for parent := node; parent != nil; {
+		parent = parent.parent
+		if parent != nil && !parent.expanded {
+			parent.Expand()
+		}
+	}
+	t.currentNode = node
+	if t.changed != nil {
+		t.changed(node)
+	}
+	return t
+}
+
+// GetCurrentNode returns the currently selected node or nil if no node is
+// currently selected.
+func (t *TreeView) GetCurrentNode() *TreeNode {
+	return t.currentNode
+}
+
+// SetGraphics sets a flag which determines whether or not guide lines are
+// drawn.
+func (t *TreeView) SetGraphics(showGraphics bool) *TreeView {
+	t.graphics = showGraphics
+	return t
+}
+
+// SetGraphicsColor sets the color of the guide lines.
+func (t *TreeView) SetGraphicsColor(color tcell.Color) *TreeView {
+	t.graphicsColor = color
+	return t
+}
+
+// SetMarkers sets the markers shown in front of nodes which can be expanded
+// ("+" by default) and nodes which are expanded ("-" by default). Both should
+// have the same width.
+func (t *TreeView) SetMarkers(collapsed, expanded string) *TreeView {
+	t.collapsedMarker, t.expandedMarker = collapsed, expanded
+	return t
+}
+
+// SetSelectedTextColor sets the text color of the selected node.
+func (t *TreeView) SetSelectedTextColor(color tcell.Color) *TreeView {
+	t.selectedTextColor = color
+	return t
+}
+
+// SetSelectedBackgroundColor sets the background color of the selected node.
+func (t *TreeView) SetSelectedBackgroundColor(color tcell.Color) *TreeView {
+	t.selectedBackgroundColor = color
+	return t
+}
+
+// SetChangedFunc sets the function which is called when the user navigates to
+// a node. The function receives the new current node (which may be nil).
+//
+// This function is also called when SetRoot() or SetCurrentNode() is called.
+func (t *TreeView) SetChangedFunc(handler func(node *TreeNode)) *TreeView {
+	t.changed = handler
+	return t
+}
+
+// SetSelectedFunc sets the function which is called when the user selects a
+// node by pressing Enter on the current node. The function receives the
+// selected node.
+func (t *TreeView) SetSelectedFunc(handler func(node *TreeNode)) *TreeView {
+	t.selected = handler
+	return t
+}
+
+// SetDoneFunc sets a function which is called when the user presses the Escape
+// key.
+func (t *TreeView) SetDoneFunc(handler func()) *TreeView {
+	t.done = handler
+	return t
+}
+
+// process builds the list of visible nodes and their guide lines. If the
+// current node is not visible anymore because one of its ancestors was
+// collapsed, that ancestor becomes the current node.
+func (t *TreeView) process() {
+	t.nodes = nil
+	if t.root == nil {
+		return
+	}
+
+	// Collect the visible nodes.
+	var add func(node *TreeNode, level int, indent string, last bool)
+	add = func(node *TreeNode, level int, indent string, last bool) {
+		node.level = level
+		node.prefix = ""
+		childIndent := ""
+		if level > 0 && t.graphics {
+			if last {
+				node.prefix = indent + string(Styles.GraphicsBottomLeftCorner) + string(Styles.GraphicsHoriBar) + " "
+				childIndent = indent + "   "
+			} else {
+				node.prefix = indent + string(Styles.GraphicsLeftT) + string(Styles.GraphicsHoriBar) + " "
+				childIndent = indent + string(Styles.GraphicsVertBar) + "  "
+			}
+		} else if level > 0 {
+			node.prefix = indent + "   "
+			childIndent = node.prefix
+		}
+		t.nodes = append(t.nodes, node)
+		if !node.expanded {
+			return
+		}
+		for index, child := range node.children {
+			add(child, level+1, childIndent, index == len(node.children)-1)
+		}
+	}
+	add(t.root, 0, "", true)
+
+	// Make sure the current node is visible.
+	if t.currentNode == nil {
+		return
+	}
+	for node := t.currentNode; node != nil; node = node.parent {
+		if t.nodeIndex(node) >= 0 {
+			if node != t.currentNode {
+				t.currentNode = node
+				if t.changed != nil {
+					t.changed(node)
+				}
+			}
+			return
+		}
+	}
+
+	// The current node is not part of this tree anymore.
+	t.currentNode = t.root
+	if t.changed != nil {
+		t.changed(t.root)
+	}
+}
+
+// nodeIndex returns the index of the given node in the list of visible nodes
+// or -1 if it is not visible.
+func (t *TreeView) nodeIndex(node *TreeNode) int {
+	for index, visible := range t.nodes {
+		if visible == node {
+			return index
+		}
+	}
+	return -1
+}
+
+// marker returns the marker to be shown in front of the given node, followed
+// by a space, or an empty string if the node cannot be expanded.
+func (t *TreeView) marker(node *TreeNode) string {
+	if node.expanded && len(node.children) > 0 {
+		return t.expandedMarker + " "
+	}
+	if !node.expanded && node.IsExpandable() {
+		return t.collapsedMarker + " "
+	}
+	return ""
+}
+
+// Draw draws this primitive onto the screen.
+func (t *TreeView) Draw(screen tcell.Screen) {
+	t.Box.Draw(screen)
+	t.process()
+
+	// Determine the dimensions.
+	x, y, width, height := t.GetInnerRect()
+	t.pageSize = height
+
+	// We want to keep the current node in view. What is our offset?
+	if current := t.nodeIndex(t.currentNode); current >= 0 {
+		if current < t.offset {
+			t.offset = current
+		} else if current >= t.offset+height {
+			t.offset = current + 1 - height
+		}
+	}
+	if t.offset > len(t.nodes)-height {
+		t.offset = len(t.nodes) - height
+	}
+	if t.offset < 0 {
+		t.offset = 0
+	}
+
+	// Draw the visible nodes.
+	for index := t.offset; index < len(t.nodes) && index-t.offset < height; index++ {
+		node := t.nodes[index]
+		rowY := y + index - t.offset
+
+		// Guide lines.
+		prefixWidth := StringWidth(node.prefix)
+		if prefixWidth > 0 {
+			Print(screen, node.prefix, x, rowY, width, AlignLeft, t.graphicsColor)
+		}
+		if prefixWidth >= width {
+			continue
+		}
+
+		// Marker.
+		markerWidth := 0
+		if marker := t.marker(node); marker != "" {
+			_, markerWidth = Print(screen, marker, x+prefixWidth, rowY, width-prefixWidth, AlignLeft, t.graphicsColor)
+		}
+		textX := x + prefixWidth + markerWidth
+		textWidth := width - prefixWidth - markerWidth
+		if textWidth <= 0 {
+			continue
+		}
+
+		// Text.
+		_, printed := Print(screen, node.text, textX, rowY, textWidth, AlignLeft, node.color)
+
+		// Background color of the current node.
+		if node == t.currentNode {
+			for bx := 0; bx < printed; bx++ {
+				m, c, style, _ := screen.GetContent(textX+bx, rowY)
+				fg, _, _ := style.Decompose()
+				if fg == node.color {
+					fg = t.selectedTextColor
+				}
+				style = style.Background(t.selectedBackgroundColor).Foreground(fg)
+				screen.SetContent(textX+bx, rowY, m, c, style)
+			}
+		}
+	}
+}
+
+// selectNode calls the "selected" handlers for the given node.
+func (t *TreeView) selectNode(node *TreeNode) {
+	if node == nil {
+		return
+	}
+	if node.selected != nil {
+		node.selected()
+	}
+	if t.selected != nil {
+		t.selected(node)
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (t *TreeView) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		t.process()
+		if len(t.nodes) == 0 {
+			if event.Key() == tcell.KeyEscape && t.done != nil {
+				t.done()
+			}
+			return
+		}
+		previousNode := t.currentNode
+		current := t.nodeIndex(t.currentNode)
+		if current < 0 {
+			current = 0
+		}
+		pageSize := t.pageSize
+		if pageSize < 1 {
+			pageSize = 1
+		}
+
+		// Moves to the node with the given index.
+		moveTo := func(index int) {
+			if index < 0 {
+				index = 0
+			} else if index >= len(t.nodes) {
+				index = len(t.nodes) - 1
+			}
+			t.currentNode = t.nodes[index]
+		}
+
+		switch key := event.Key(); key {
+		case tcell.KeyDown, tcell.KeyTab:
+			moveTo(current + 1)
+		case tcell.KeyUp, tcell.KeyBacktab:
+			moveTo(current - 1)
+		case tcell.KeyHome:
+			moveTo(0)
+		case tcell.KeyEnd:
+			moveTo(len(t.nodes) - 1)
+		case tcell.KeyPgDn:
+			moveTo(current + pageSize)
+		case tcell.KeyPgUp:
+			moveTo(current - pageSize)
+		case tcell.KeyLeft:
+			node := t.nodes[current]
+			if node.expanded && len(node.children) > 0 {
+				node.Collapse()
+			} else if node.parent != nil {
+				t.currentNode = node.parent
+			}
+		case tcell.KeyRight:
+			node := t.nodes[current]
+			if !node.expanded && node.IsExpandable() {
+				node.Expand()
+			} else if node.expanded && len(node.children) > 0 {
+				t.currentNode = node.children[0]
+			}
+		case tcell.KeyEnter:
+			t.currentNode = t.nodes[current]
+			t.selectNode(t.currentNode)
+		case tcell.KeyEscape:
+			if t.done != nil {
+				t.done()
+			}
+		case tcell.KeyRune:
+			node := t.nodes[current]
+			switch event.Rune() {
+			case '+':
+				node.Expand()
+			case '-':
+				node.Collapse()
+			case ' ':
+				node.SetExpanded(!node.expanded)
+			}
+		}
+
+		if t.currentNode != previousNode && t.changed != nil {
+			t.changed(t.currentNode)
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (t *TreeView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) bool {
+	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool) {
+		x, y := event.Position()
+		if !t.InRect(x, y) {
+			return false
+		}
+		previousNode := t.currentNode
+
+		// Process mouse event.
+		switch action {
+		case MouseLeftDown:
+			setFocus(t)
+			consumed = true
+		case MouseLeftClick:
+			consumed = true
+			rectX, rectY, width, height := t.GetInnerRect()
+			index := y - rectY + t.offset
+			if x < rectX || x >= rectX+width || y < rectY || y >= rectY+height || index >= len(t.nodes) {
+				break
+			}
+			node := t.nodes[index]
+
+			// Clicking the marker toggles the node.
+			markerX := rectX + StringWidth(node.prefix)
+			if marker := t.marker(node); marker != "" && x >= markerX && x < markerX+StringWidth(marker) {
+				node.SetExpanded(!node.expanded)
+				break
+			}
+
+			// Clicking the current node selects it.
+			if node == t.currentNode {
+				t.selectNode(node)
+				break
+			}
+			t.currentNode = node
+		case MouseScrollUp, MouseScrollDown:
+			consumed = true
+			t.process()
+			index := t.nodeIndex(t.currentNode)
+			if action == MouseScrollUp && index > 0 {
+				t.currentNode = t.nodes[index-1]
+			} else if action == MouseScrollDown && index >= 0 && index < len(t.nodes)-1 {
+				t.currentNode = t.nodes[index+1]
+			}
+		}
+
+		if t.currentNode != previousNode && t.changed != nil {
+			t.changed(t.currentNode)
+		}
+		return
+	})
+}
@@ -0,0 +1,11 @@
+package tviewtest
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	RegisterFlags()
+	os.Exit(m.Run())
+}
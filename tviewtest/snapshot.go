@@ -0,0 +1,234 @@
+package tviewtest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/litmusautomation/tview"
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// UpdateSnapshots causes AssertSnapshot() to write golden files instead of
+// comparing against them. It is set by the -update flag (see RegisterFlags())
+// and is also initially true if the UPDATE_SNAPSHOTS environment variable is
+// set to a non-empty value.
+var UpdateSnapshots = os.Getenv("UPDATE_SNAPSHOTS") != ""
+
+// RegisterFlags registers the -update flag, which sets UpdateSnapshots, on the
+// command line flag set. Test packages call it from TestMain() so that golden
+// files can be regenerated with
+//
+//	go test -update
+//
+// The flag is not registered by this package itself as that would add it to
+// every binary which imports tviewtest.
+func RegisterFlags() {
+	flag.BoolVar(&UpdateSnapshots, "update", UpdateSnapshots, "write golden files instead of comparing against them")
+}
+
+// GoldenDir is the directory in which AssertSnapshot() keeps its golden files,
+// relative to the package under test.
+var GoldenDir = "testdata"
+
+// legendKeys are the characters used to refer to styles in snapshots, in the
+// order in which they are assigned.
+const legendKeys = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// colorNames maps colors to their (alphabetically first) name.
+var colorNames map[tcell.Color]string
+
+func init() {
+	colorNames = make(map[tcell.Color]string)
+	for name, color := range tcell.ColorNames {
+		if existing, ok := colorNames[color]; !ok || name < existing {
+			colorNames[color] = name
+		}
+	}
+}
+
+// Render draws the given primitive onto a simulation screen of the given size
+// and returns a snapshot of the result. The primitive is positioned at the
+// top-left corner and resized to fill the screen.
+//
+// A snapshot consists of three sections: The characters on the screen, one
+// line per row, then one line per row which identifies the style of each cell
+// by a character, followed by a legend which describes these styles. Wide
+// characters take up one character in the text section but two in the style
+// section.
+func Render(p tview.Primitive, width, height int) string {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		panic(err)
+	}
+	defer screen.Fini()
+	screen.SetSize(width, height)
+
+	p.SetRect(0, 0, width, height)
+	p.Draw(screen)
+	screen.Show()
+
+	cells, width, height := screen.GetContents()
+	return snapshot(cells, width, height)
+}
+
+// Snapshot returns a snapshot of what the application currently shows on the
+// screen. See Render() for the format.
+func (h *Harness) Snapshot() string {
+	cells, width, height := h.screen.GetContents()
+	return snapshot(cells, width, height)
+}
+
+// AssertSnapshot renders the given primitive at the given size (see Render())
+// and compares the result to the golden file "name".golden in GoldenDir. The
+// test fails if they differ. If UpdateSnapshots is true, the golden file is
+// written instead.
+func AssertSnapshot(t testing.TB, p tview.Primitive, width, height int, name string) {
+	t.Helper()
+	compareGolden(t, Render(p, width, height), name)
+}
+
+// AssertSnapshot compares a snapshot of the current screen (see Snapshot())
+// to the golden file "name".golden in GoldenDir. The test fails if they
+// differ. If UpdateSnapshots is true, the golden file is written instead.
+func (h *Harness) AssertSnapshot(t testing.TB, name string) {
+	t.Helper()
+	compareGolden(t, h.Snapshot(), name)
+}
+
+// compareGolden compares a snapshot to a golden file or, if requested, writes
+// the golden file.
+func compareGolden(t testing.TB, got, name string) {
+	t.Helper()
+	path := filepath.Join(GoldenDir, name+".golden")
+
+	if UpdateSnapshots {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("could not create golden file directory: %v", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("could not write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read golden file (run with -update to create it): %v", err)
+	}
+	if got == string(want) {
+		return
+	}
+
+	// Find the first line that differs.
+	gotLines, wantLines := strings.Split(got, "\n"), strings.Split(string(want), "\n")
+	line := 0
+	for line < len(gotLines) && line < len(wantLines) && gotLines[line] == wantLines[line] {
+		line++
+	}
+	t.Errorf("snapshot differs from %s in line %d (run with -update to regenerate)\n--- got:\n%s\n--- want:\n%s", path, line+1, got, want)
+}
+
+// snapshot converts the given screen cells into the snapshot format described
+// in Render().
+func snapshot(cells []tcell.SimCell, width, height int) string {
+	var (
+		text, styles bytes.Buffer
+		legend       []tcell.Style
+		keys         = make(map[tcell.Style]rune)
+	)
+	for y := 0; y < height; y++ {
+		skip := false
+		for x := 0; x < width; x++ {
+			cell := cells[y*width+x]
+
+			// The style.
+			key, ok := keys[cell.Style]
+			if !ok {
+				key = styleKey(len(legend))
+				keys[cell.Style] = key
+				legend = append(legend, cell.Style)
+			}
+			styles.WriteRune(key)
+
+			// The character.
+			if skip {
+				skip = false // The second half of a wide character.
+				continue
+			}
+			if len(cell.Runes) == 0 {
+				text.WriteRune(' ')
+				continue
+			}
+			for _, r := range cell.Runes {
+				text.WriteRune(r)
+			}
+			skip = runewidth.RuneWidth(cell.Runes[0]) == 2
+		}
+		text.WriteByte('\n')
+		styles.WriteByte('\n')
+	}
+
+	var result bytes.Buffer
+	result.WriteString("-- text --\n")
+	result.Write(text.Bytes())
+	result.WriteString("-- styles --\n")
+	result.Write(styles.Bytes())
+	result.WriteString("-- legend --\n")
+	for index, style := range legend {
+		fg, bg, attr := style.Decompose()
+		fmt.Fprintf(&result, "%c fg=%s bg=%s attr=%s\n", styleKey(index), colorName(fg), colorName(bg), attrName(attr))
+	}
+	return result.String()
+}
+
+// styleKey returns the character which refers to the style with the given
+// index in the legend.
+func styleKey(index int) rune {
+	if index < len(legendKeys) {
+		return rune(legendKeys[index])
+	}
+	return rune(0x100 + index) // Unlikely, but still unique.
+}
+
+// colorName returns a readable name for a color.
+func colorName(color tcell.Color) string {
+	if color == tcell.ColorDefault {
+		return "default"
+	}
+	if name, ok := colorNames[color]; ok {
+		return name
+	}
+	return fmt.Sprintf("#%06x", color.Hex())
+}
+
+// attrName returns a short description of text attributes: "b" for bold, "l"
+// for blink, "r" for reverse, "u" for underline, and "d" for dim. "-" is
+// returned if no attributes are set.
+func attrName(attr tcell.AttrMask) string {
+	var name string
+	for _, a := range []struct {
+		mask tcell.AttrMask
+		name string
+	}{
+		{tcell.AttrBold, "b"},
+		{tcell.AttrBlink, "l"},
+		{tcell.AttrReverse, "r"},
+		{tcell.AttrUnderline, "u"},
+		{tcell.AttrDim, "d"},
+	} {
+		if attr&a.mask != 0 {
+			name += a.name
+		}
+	}
+	if name == "" {
+		return "-"
+	}
+	return name
+}
@@ -0,0 +1,78 @@
+package tviewtest
+
+import (
+	"testing"
+
+	"github.com/litmusautomation/tview"
+)
+
+func TestSnapshotBox(t *testing.T) {
+	box := tview.NewBox().SetBorder(true).SetTitle("Box")
+	AssertSnapshot(t, box, 12, 4, "box")
+}
+
+func TestSnapshotForm(t *testing.T) {
+	form := tview.NewForm().
+		AddFormItem(tview.NewInputField().SetLabel("Name").SetFieldWidth(10)).
+		AddFormItem(tview.NewCheckbox().SetLabel("Admin")).
+		AddButton("Save", nil).
+		AddButton("Cancel", nil)
+	form.SetBorder(true).SetTitle("User")
+	AssertSnapshot(t, form, 30, 10, "form")
+}
+
+func TestSnapshotModal(t *testing.T) {
+	modal := tview.NewModal().
+		SetText("Quit?").
+		AddButtons([]string{"Yes", "No"})
+	AssertSnapshot(t, modal, 60, 9, "modal")
+}
+
+func TestSnapshotGrid(t *testing.T) {
+	grid := tview.NewGrid().
+		SetRows(1, 1).
+		SetColumns(5, 5).
+		SetBorders(true).
+		AddItem(tview.NewTextView().SetText("a"), 0, 0, 1, 1, 0, 0, false).
+		AddItem(tview.NewTextView().SetText("b"), 0, 1, 1, 1, 0, 0, false).
+		AddItem(tview.NewTextView().SetText("wide"), 1, 0, 1, 2, 0, 0, false)
+	AssertSnapshot(t, grid, 13, 5, "grid")
+}
+
+func TestSnapshotHarness(t *testing.T) {
+	form := tview.NewForm().
+		AddFormItem(tview.NewInputField().SetLabel("Name").SetFieldWidth(10)).
+		AddButton("Save", nil)
+	h := start(t, tview.NewApplication().SetRoot(form, true), 20, 5)
+	h.InjectString("Bob")
+	h.AssertSnapshot(t, "harness")
+}
+
+// spanTable returns a bordered table with a cell spanning two columns and a
+// cell spanning two rows.
+func spanTable() *tview.Table {
+	table := tview.NewTable().SetBorders(true)
+	table.SetCell(0, 0, tview.NewTableCell("wide").SetColumnSpan(2))
+	table.SetCellSimple(0, 2, "c")
+	table.SetCell(1, 0, tview.NewTableCell("tall").SetRowSpan(2))
+	table.SetCellSimple(1, 1, "a")
+	table.SetCellSimple(1, 2, "b")
+	table.SetCellSimple(2, 1, "d")
+	table.SetCellSimple(2, 2, "e")
+	return table
+}
+
+func TestSnapshotTableSpanBorders(t *testing.T) {
+	AssertSnapshot(t, spanTable(), 16, 8, "table_span_borders")
+}
+
+func TestSnapshotTableSpanSelection(t *testing.T) {
+	table := spanTable().SetSelectable(true, true).Select(2, 0)
+	AssertSnapshot(t, table, 16, 8, "table_span_selection")
+}
+
+func TestSnapshotTableSpanClipped(t *testing.T) {
+	table := spanTable()
+	table.SetCell(0, 0, tview.NewTableCell("very wide cell").SetColumnSpan(2))
+	AssertSnapshot(t, table, 12, 6, "table_span_clipped")
+}
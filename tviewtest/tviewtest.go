@@ -0,0 +1,327 @@
+/*
+Package tviewtest provides a harness for running a tview application against a
+tcell.SimulationScreen so that the behaviour of primitives can be checked in
+unit tests.
+
+A Harness starts the application in the background, injects key and mouse
+events, and waits for the application to process them before returning. The
+rendered screen can then be retrieved as text:
+
+	table := tview.NewTable()
+	// ...
+	app := tview.NewApplication().SetRoot(table, true)
+	h, err := tviewtest.Start(app, 40, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Stop()
+
+	h.InjectKeys(tcell.KeyDown, tcell.KeyDown)
+	if !strings.Contains(h.Line(0), "Name") {
+		t.Errorf("header not visible:\n%s", h.Text())
+	}
+
+All Inject* functions are synchronous: When they return, the event loop has
+handled the events, including any redraw. Changes made by other goroutines
+through Application.QueueUpdateDraw() can be awaited with WaitForDraw().
+
+AssertSnapshot() compares the rendering of a primitive (including colors and
+attributes) to a golden file in the package's "testdata" directory. To write
+or regenerate golden files, register the -update flag in TestMain():
+
+	func TestMain(m *testing.M) {
+		tviewtest.RegisterFlags()
+		os.Exit(m.Run())
+	}
+
+and run "go test -update". Alternatively, set the UPDATE_SNAPSHOTS environment
+variable, which also works for packages that do not register the flag.
+*/
+package tviewtest
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/litmusautomation/tview"
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// syncEvent is posted to the screen's event queue to find out when all events
+// posted before it have been processed.
+type syncEvent struct {
+	tcell.EventTime
+	done chan struct{}
+}
+
+// screen wraps a tcell.SimulationScreen. It sets the requested size when the
+// application initializes it and intercepts sync events.
+type screen struct {
+	tcell.SimulationScreen
+
+	// The requested screen size.
+	width, height int
+
+	// Closed when the screen was initialized by the application.
+	ready     chan struct{}
+	readyOnce sync.Once
+}
+
+// Init initializes the simulation screen and resizes it to the requested size.
+func (s *screen) Init() error {
+	if err := s.SimulationScreen.Init(); err != nil {
+		return err
+	}
+	s.SetSize(s.width, s.height)
+	s.readyOnce.Do(func() {
+		close(s.ready)
+	})
+	return nil
+}
+
+// PollEvent waits for the next event. Sync events are not passed on to the
+// application. As the application only polls for the next event after it has
+// finished processing the previous one, receiving a sync event means that all
+// events before it have been handled.
+func (s *screen) PollEvent() tcell.Event {
+	for {
+		event := s.SimulationScreen.PollEvent()
+		if pending, ok := event.(*syncEvent); ok {
+			close(pending.done)
+			continue
+		}
+		return event
+	}
+}
+
+// Harness runs an application against a simulation screen.
+type Harness struct {
+	// The application under test.
+	app *tview.Application
+
+	// The screen the application draws on.
+	screen *screen
+
+	// Receives a value after the application was drawn. Notifications are
+	// coalesced.
+	draws chan struct{}
+
+	// Closed when Application.Run() has returned. The error returned by it is
+	// then stored in runErr.
+	stopped chan struct{}
+	runErr  error
+}
+
+// Start runs the given application against a new simulation screen of the
+// given size. The application's root primitive must have been set. Start
+// returns after the application has been drawn for the first time.
+//
+// Any function installed with Application.SetAfterDrawFunc() before Start is
+// called is still invoked.
+func Start(app *tview.Application, width, height int) (*Harness, error) {
+	h := &Harness{
+		app: app,
+		screen: &screen{
+			SimulationScreen: tcell.NewSimulationScreen("UTF-8"),
+			width:            width,
+			height:           height,
+			ready:            make(chan struct{}),
+		},
+		draws:   make(chan struct{}, 1),
+		stopped: make(chan struct{}),
+	}
+
+	// Get notified of redraws.
+	afterDraw := app.GetAfterDrawFunc()
+	app.SetAfterDrawFunc(func(screen tcell.Screen) {
+		if afterDraw != nil {
+			afterDraw(screen)
+		}
+		select {
+		case h.draws <- struct{}{}:
+		default:
+		}
+	})
+
+	// Run the application.
+	app.SetScreen(h.screen)
+	go func() {
+		h.runErr = app.Run()
+		close(h.stopped)
+	}()
+
+	// Wait for the first draw.
+	select {
+	case <-h.screen.ready:
+	case <-h.stopped:
+		return nil, h.runErr
+	}
+	h.Sync()
+
+	return h, nil
+}
+
+// App returns the application under test.
+func (h *Harness) App() *tview.Application {
+	return h.app
+}
+
+// Screen returns the simulation screen the application draws on.
+func (h *Harness) Screen() tcell.SimulationScreen {
+	return h.screen.SimulationScreen
+}
+
+// Stop stops the application and waits for Application.Run() to return. The
+// error returned by Run() is passed on.
+func (h *Harness) Stop() error {
+	h.app.Stop()
+	<-h.stopped
+	return h.runErr
+}
+
+// Sync waits until the application has processed all events injected so far.
+// Pending draw notifications are discarded, see WaitForDraw().
+func (h *Harness) Sync() {
+	done := make(chan struct{})
+	event := &syncEvent{done: done}
+	event.SetEventNow()
+	h.post(event)
+	select {
+	case <-done:
+	case <-h.stopped:
+	}
+	select {
+	case <-h.draws:
+	default:
+	}
+}
+
+// WaitForDraw waits until the application is drawn again, e.g. after another
+// goroutine called Application.QueueUpdateDraw(). It returns false if this did
+// not happen within the given timeout or if the application was stopped.
+func (h *Harness) WaitForDraw(timeout time.Duration) bool {
+	select {
+	case <-h.draws:
+		return true
+	case <-h.stopped:
+		return false
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// post puts an event into the screen's event queue unless the application has
+// stopped.
+func (h *Harness) post(event tcell.Event) {
+	select {
+	case <-h.stopped:
+	default:
+		h.screen.PostEventWait(event)
+	}
+}
+
+// InjectKey sends a single key event to the application and waits until it was
+// processed. For tcell.KeyRune, "r" is the typed character.
+func (h *Harness) InjectKey(key tcell.Key, r rune, mod tcell.ModMask) {
+	h.post(tcell.NewEventKey(key, r, mod))
+	h.Sync()
+}
+
+// InjectKeys sends the given keys (without modifiers) to the application and
+// waits until they were processed.
+func (h *Harness) InjectKeys(keys ...tcell.Key) {
+	for _, key := range keys {
+		h.post(tcell.NewEventKey(key, 0, tcell.ModNone))
+	}
+	h.Sync()
+}
+
+// InjectString types the given text, one tcell.KeyRune event per character,
+// and waits until it was processed.
+func (h *Harness) InjectString(text string) {
+	for _, r := range text {
+		h.post(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+	h.Sync()
+}
+
+// InjectMouse sends a mouse event with the given button state to the
+// application and waits until it was processed. Mouse events are only handled
+// if the application has mouse support enabled.
+func (h *Harness) InjectMouse(x, y int, buttons tcell.ButtonMask) {
+	h.post(tcell.NewEventMouse(x, y, buttons, tcell.ModNone))
+	h.Sync()
+}
+
+// Click presses and releases the left mouse button at the given position and
+// waits until the events were processed.
+func (h *Harness) Click(x, y int) {
+	h.post(tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone))
+	h.post(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+	h.Sync()
+}
+
+// Resize changes the size of the screen and waits until the application has
+// redrawn itself.
+func (h *Harness) Resize(width, height int) {
+	h.screen.SetSize(width, height)
+	h.post(tcell.NewEventResize(width, height))
+	h.Sync()
+}
+
+// Size returns the current size of the screen.
+func (h *Harness) Size() (width, height int) {
+	return h.screen.Size()
+}
+
+// Cell returns the character and style shown at the given position. A space
+// is returned for empty cells and for positions outside the screen.
+func (h *Harness) Cell(x, y int) (rune, tcell.Style) {
+	cells, width, height := h.screen.GetContents()
+	if x < 0 || y < 0 || x >= width || y >= height {
+		return ' ', tcell.StyleDefault
+	}
+	cell := cells[y*width+x]
+	if len(cell.Runes) == 0 {
+		return ' ', cell.Style
+	}
+	return cell.Runes[0], cell.Style
+}
+
+// Line returns the text shown in the given row of the screen. Trailing spaces
+// are not removed. Wide characters occupy a single character in the returned
+// string.
+func (h *Harness) Line(y int) string {
+	cells, width, height := h.screen.GetContents()
+	if y < 0 || y >= height {
+		return ""
+	}
+	var line strings.Builder
+	for x := 0; x < width; x++ {
+		cell := cells[y*width+x]
+		if len(cell.Runes) == 0 {
+			line.WriteRune(' ')
+			continue
+		}
+		for _, r := range cell.Runes {
+			line.WriteRune(r)
+		}
+		if runewidth.RuneWidth(cell.Runes[0]) == 2 {
+			x++ // Skip the second half of a wide character.
+		}
+	}
+	return line.String()
+}
+
+// Text returns the entire screen as text, one line per row, separated by
+// newline characters. See Line() for details.
+func (h *Harness) Text() string {
+	_, height := h.Size()
+	lines := make([]string, height)
+	for y := range lines {
+		lines[y] = h.Line(y)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,145 @@
+package tviewtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/litmusautomation/tview"
+)
+
+// start runs the given application on a screen of the given size and stops it
+// when the test has finished.
+func start(t *testing.T, app *tview.Application, width, height int) *Harness {
+	t.Helper()
+	h, err := Start(app, width, height)
+	if err != nil {
+		t.Fatalf("could not start application: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := h.Stop(); err != nil {
+			t.Errorf("application returned an error: %v", err)
+		}
+	})
+	return h
+}
+
+// assertLine fails the test if the given screen row does not start with the
+// given text (ignoring trailing spaces).
+func assertLine(t *testing.T, h *Harness, y int, want string) {
+	t.Helper()
+	if got := strings.TrimRight(h.Line(y), " "); got != want {
+		t.Errorf("line %d is %q, want %q\n%s", y, got, want, h.Text())
+	}
+}
+
+func TestFormFocusTraversal(t *testing.T) {
+	form := tview.NewForm().
+		AddFormItem(tview.NewInputField().SetLabel("Name").SetFieldWidth(10)).
+		AddFormItem(tview.NewInputField().SetLabel("Age").SetFieldWidth(5)).
+		AddButton("Save", nil)
+	h := start(t, tview.NewApplication().SetRoot(form, true), 30, 8)
+
+	// Typing goes into the first field, Tab moves on to the next one.
+	h.InjectString("Bob")
+	h.InjectKeys(tcell.KeyTab)
+	h.InjectString("42")
+	assertLine(t, h, 1, " Name Bob")
+	assertLine(t, h, 3, " Age 42")
+
+	// On the button, typed characters are ignored.
+	h.InjectKeys(tcell.KeyTab)
+	h.InjectString("x")
+	assertLine(t, h, 1, " Name Bob")
+	assertLine(t, h, 3, " Age 42")
+	assertLine(t, h, 6, "   Save")
+
+	// Backtab returns to the previous field, Tab wraps around to the first.
+	h.InjectKeys(tcell.KeyBacktab)
+	h.InjectString("1")
+	assertLine(t, h, 3, " Age 421")
+	h.InjectKeys(tcell.KeyTab, tcell.KeyTab)
+	h.InjectString("by")
+	assertLine(t, h, 1, " Name Bobby")
+}
+
+func TestTableScrolling(t *testing.T) {
+	table := tview.NewTable().SetFixed(1, 0).SetSelectable(true, false)
+	table.SetCell(0, 0, tview.NewTableCell("Header").SetSelectable(false))
+	for row := 1; row <= 20; row++ {
+		table.SetCellSimple(row, 0, fmt.Sprintf("row %d", row))
+	}
+	h := start(t, tview.NewApplication().SetRoot(table, true), 20, 5)
+	assertLine(t, h, 0, "Header")
+	assertLine(t, h, 1, "row 1")
+
+	// The selection stays on screen, the fixed row stays in place.
+	h.InjectKeys(tcell.KeyDown, tcell.KeyDown, tcell.KeyDown, tcell.KeyDown, tcell.KeyDown, tcell.KeyDown)
+	assertLine(t, h, 0, "Header")
+	assertLine(t, h, 1, "row 4")
+	assertLine(t, h, 4, "row 7")
+
+	h.InjectKeys(tcell.KeyEnd)
+	assertLine(t, h, 0, "Header")
+	assertLine(t, h, 4, "row 20")
+
+	// A page up keeps the previously selected row on screen.
+	h.InjectKeys(tcell.KeyPgUp)
+	assertLine(t, h, 1, "row 15")
+
+	h.InjectKeys(tcell.KeyHome)
+	assertLine(t, h, 1, "row 1")
+}
+
+func TestDropDownOpening(t *testing.T) {
+	dropDown := tview.NewDropDown().
+		SetLabel("Color: ").
+		SetOptions([]*tview.DropDownOption{
+			tview.NewDropDownOption("r", "Red"),
+			tview.NewDropDownOption("g", "Green"),
+			tview.NewDropDownOption("b", "Blue"),
+		}, nil)
+	h := start(t, tview.NewApplication().SetRoot(dropDown, true).EnableMouse(true), 30, 6)
+	if text := h.Text(); strings.Contains(text, "Red") {
+		t.Fatalf("list is shown before the drop-down was opened:\n%s", text)
+	}
+
+	// Enter opens the list, selecting an option closes it.
+	h.InjectKeys(tcell.KeyEnter)
+	assertLine(t, h, 1, "        Red")
+	assertLine(t, h, 2, "        Green")
+	assertLine(t, h, 3, "        Blue")
+	h.InjectKeys(tcell.KeyDown, tcell.KeyEnter)
+	assertLine(t, h, 0, "Color:  Green")
+	assertLine(t, h, 1, "")
+
+	// Clicking opens the list, clicking an option selects it.
+	h.Click(8, 0)
+	assertLine(t, h, 3, "        Blue")
+	h.Click(8, 3)
+	assertLine(t, h, 0, "Color:  Blue")
+	assertLine(t, h, 3, "")
+}
+
+func TestAutocompleteCoversLaterPrimitives(t *testing.T) {
+	input := tview.NewInputField().SetLabel("Name: ").SetAutocompleteFunc(func(text string) []string {
+		if text == "" {
+			return nil
+		}
+		return []string{"Alice", "Alfred"}
+	})
+	cover := tview.NewTextView().SetText("xxxxxxxxxxxxxxxxxxxx\nxxxxxxxxxxxxxxxxxxxx")
+	cover.SetRect(0, 1, 20, 2)
+	pages := tview.NewPages().
+		AddPage("input", input, true, true).
+		AddPage("cover", cover, false, true)
+	h := start(t, tview.NewApplication().SetRoot(pages, true).SetFocus(input), 20, 4)
+
+	// The text view is drawn after the input field but the list covers it.
+	h.InjectString("A")
+	assertLine(t, h, 1, "xxxxxxxAlice")
+	assertLine(t, h, 2, "xxxxxxxAlfred")
+	h.InjectKeys(tcell.KeyEscape)
+	assertLine(t, h, 1, "xxxxxxxxxxxxxxxxxxxx")
+}
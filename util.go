@@ -259,6 +259,13 @@ func decomposeString(text string) (colorIndices [][]int, colors [][]string, esca
 	return
 }
 
+// stripTags returns the given text without any color tags and with escaped
+// tags unescaped, i.e. the text as it would be printed.
+func stripTags(text string) string {
+	_, _, _, stripped, _ := decomposeString(text)
+	return stripped
+}
+
 // Print prints text onto the screen into the given box at (x,y,maxWidth,1),
 // not exceeding that box. "align" is one of AlignLeft, AlignCenter, or
 // AlignRight. The screen's background color will not be changed.